@@ -21,13 +21,112 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED  EventType = 0
+	EventType_EVENT_TYPE_ADDED        EventType = 1
+	EventType_EVENT_TYPE_UPDATED      EventType = 2
+	EventType_EVENT_TYPE_DELETED      EventType = 3
+	EventType_EVENT_TYPE_FIRED        EventType = 4
+	EventType_EVENT_TYPE_MISSED       EventType = 5
+	EventType_EVENT_TYPE_SNOOZED      EventType = 6
+	EventType_EVENT_TYPE_ACKNOWLEDGED EventType = 7
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_ADDED",
+		2: "EVENT_TYPE_UPDATED",
+		3: "EVENT_TYPE_DELETED",
+		4: "EVENT_TYPE_FIRED",
+		5: "EVENT_TYPE_MISSED",
+		6: "EVENT_TYPE_SNOOZED",
+		7: "EVENT_TYPE_ACKNOWLEDGED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":  0,
+		"EVENT_TYPE_ADDED":        1,
+		"EVENT_TYPE_UPDATED":      2,
+		"EVENT_TYPE_DELETED":      3,
+		"EVENT_TYPE_FIRED":        4,
+		"EVENT_TYPE_MISSED":       5,
+		"EVENT_TYPE_SNOOZED":      6,
+		"EVENT_TYPE_ACKNOWLEDGED": 7,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{0}
+}
+
 type ScheduleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Datetime      string                 `protobuf:"bytes,3,opt,name=datetime,proto3" json:"datetime,omitempty"`
-	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
-	Memo          string                 `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Datetime string                 `protobuf:"bytes,3,opt,name=datetime,proto3" json:"datetime,omitempty"`
+	Url      string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Memo     string                 `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	Rrule    string                 `protobuf:"bytes,6,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	// state is empty while the reminder is pending, and awaiting_ack or
+	// snoozed after it fired until someone acknowledges it.
+	State string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	// ack_due is when the next re-notification is sent, in RFC 3339.
+	AckDue string `protobuf:"bytes,8,opt,name=ack_due,json=ackDue,proto3" json:"ack_due,omitempty"`
+	// notify_count is how many times the current firing was notified.
+	NotifyCount int32 `protobuf:"varint,9,opt,name=notify_count,json=notifyCount,proto3" json:"notify_count,omitempty"`
+	// alerts are offsets from datetime at which to notify, such as "-1d",
+	// "-30m" and "0". No alerts means a single alert at datetime.
+	Alerts []string `protobuf:"bytes,10,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	// fired_alerts are the offsets already sent for the current occurrence.
+	FiredAlerts []string `protobuf:"bytes,11,rep,name=fired_alerts,json=firedAlerts,proto3" json:"fired_alerts,omitempty"`
+	// next_alert is the next time an alert fires. It is filled in by
+	// ListSchedules and never stored.
+	NextAlert string `protobuf:"bytes,12,opt,name=next_alert,json=nextAlert,proto3" json:"next_alert,omitempty"`
+	// tz is the IANA zone the schedule was written in. datetime is stored as
+	// an RFC 3339 instant, and recurrences keep their wall-clock time in tz.
+	Tz string `protobuf:"bytes,13,opt,name=tz,proto3" json:"tz,omitempty"`
+	// tags are free-form labels used to filter the list.
+	Tags []string `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	// owner is the user who added the schedule. The server sets it from the
+	// caller's token; it is empty when authentication is off.
+	Owner string `protobuf:"bytes,15,opt,name=owner,proto3" json:"owner,omitempty"`
+	// assignees are the users and "@group"s a team reminder is for. Every
+	// member sees it, receives its events and acknowledges it separately.
+	Assignees []string `protobuf:"bytes,16,rep,name=assignees,proto3" json:"assignees,omitempty"`
+	// acked_by are the members who acknowledged the current occurrence.
+	AckedBy []string `protobuf:"bytes,17,rep,name=acked_by,json=ackedBy,proto3" json:"acked_by,omitempty"`
+	// members is assignees with groups expanded. It is filled in by
+	// ListSchedules and never stored.
+	Members []string `protobuf:"bytes,18,rep,name=members,proto3" json:"members,omitempty"`
+	// emails are the addresses the smtp notifier sends this reminder to,
+	// instead of its configured default recipients.
+	Emails        []string `protobuf:"bytes,19,rep,name=emails,proto3" json:"emails,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,6 +196,397 @@ func (x *ScheduleRequest) GetMemo() string {
 	return ""
 }
 
+func (x *ScheduleRequest) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetAckDue() string {
+	if x != nil {
+		return x.AckDue
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetNotifyCount() int32 {
+	if x != nil {
+		return x.NotifyCount
+	}
+	return 0
+}
+
+func (x *ScheduleRequest) GetAlerts() []string {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetFiredAlerts() []string {
+	if x != nil {
+		return x.FiredAlerts
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetNextAlert() string {
+	if x != nil {
+		return x.NextAlert
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetTz() string {
+	if x != nil {
+		return x.Tz
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetAssignees() []string {
+	if x != nil {
+		return x.Assignees
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetAckedBy() []string {
+	if x != nil {
+		return x.AckedBy
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+// ListSchedulesRequest narrows and orders ListSchedules. An empty request
+// returns every schedule in the order they were added.
+type ListSchedulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// from and to bound the due time (datetime) as RFC 3339 instants; from
+	// is inclusive, to exclusive. Either may be empty.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// query matches title or memo as a case-insensitive substring.
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// regex matches title or memo as an RE2 regular expression.
+	Regex string `protobuf:"bytes,4,opt,name=regex,proto3" json:"regex,omitempty"`
+	// Only schedules carrying every one of tags are returned.
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// sort is "added" (default), "datetime" or "title", with a leading "-"
+	// for descending order.
+	Sort string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	// page_size limits the page; 0 returns every match.
+	PageSize int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is next_page_token from the previous page.
+	PageToken string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// mine keeps the schedules the caller owns or is a member of.
+	Mine bool `protobuf:"varint,9,opt,name=mine,proto3" json:"mine,omitempty"`
+	// team keeps the schedules that have assignees.
+	Team          bool `protobuf:"varint,10,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_schedule_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSchedulesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetRegex() string {
+	if x != nil {
+		return x.Regex
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListSchedulesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSchedulesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetMine() bool {
+	if x != nil {
+		return x.Mine
+	}
+	return false
+}
+
+func (x *ListSchedulesRequest) GetTeam() bool {
+	if x != nil {
+		return x.Team
+	}
+	return false
+}
+
+// UpdateScheduleRequest changes the schedule with the given id. Only the
+// fields named in update_mask (title, datetime, url, memo, rrule, alerts,
+// tz, tags, assignees, emails) are written; an empty mask updates every
+// non-empty field of schedule.
+type UpdateScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Schedule      *ScheduleRequest       `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	UpdateMask    []string               `protobuf:"bytes,3,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduleRequest) Reset() {
+	*x = UpdateScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetSchedule() *ScheduleRequest {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *UpdateScheduleRequest) GetUpdateMask() []string {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// ScheduleId addresses a schedule by its full ID or an unambiguous prefix
+// of it.
+type ScheduleId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleId) Reset() {
+	*x = ScheduleId{}
+	mi := &file_schedule_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleId) ProtoMessage() {}
+
+func (x *ScheduleId) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleId.ProtoReflect.Descriptor instead.
+func (*ScheduleId) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScheduleId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// SnoozeRequest postpones the next notification of a fired reminder.
+// duration uses Go duration syntax such as "10m" or "1h30m".
+type SnoozeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Duration      string                 `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeRequest) Reset() {
+	*x = SnoozeRequest{}
+	mi := &file_schedule_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeRequest) ProtoMessage() {}
+
+func (x *SnoozeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SnoozeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SnoozeRequest) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
 type ScheduleIdx struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Idx           int32                  `protobuf:"varint,1,opt,name=idx,proto3" json:"idx,omitempty"`
@@ -106,7 +596,7 @@ type ScheduleIdx struct {
 
 func (x *ScheduleIdx) Reset() {
 	*x = ScheduleIdx{}
-	mi := &file_schedule_proto_msgTypes[1]
+	mi := &file_schedule_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -118,7 +608,7 @@ func (x *ScheduleIdx) String() string {
 func (*ScheduleIdx) ProtoMessage() {}
 
 func (x *ScheduleIdx) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[1]
+	mi := &file_schedule_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -131,7 +621,7 @@ func (x *ScheduleIdx) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleIdx.ProtoReflect.Descriptor instead.
 func (*ScheduleIdx) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{1}
+	return file_schedule_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ScheduleIdx) GetIdx() int32 {
@@ -142,15 +632,17 @@ func (x *ScheduleIdx) GetIdx() int32 {
 }
 
 type ScheduleList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Schedules     []*ScheduleRequest     `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Schedules []*ScheduleRequest     `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	// next_page_token fetches the following page; empty on the last one.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ScheduleList) Reset() {
 	*x = ScheduleList{}
-	mi := &file_schedule_proto_msgTypes[2]
+	mi := &file_schedule_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -162,7 +654,7 @@ func (x *ScheduleList) String() string {
 func (*ScheduleList) ProtoMessage() {}
 
 func (x *ScheduleList) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[2]
+	mi := &file_schedule_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,7 +667,7 @@ func (x *ScheduleList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleList.ProtoReflect.Descriptor instead.
 func (*ScheduleList) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{2}
+	return file_schedule_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ScheduleList) GetSchedules() []*ScheduleRequest {
@@ -185,6 +677,13 @@ func (x *ScheduleList) GetSchedules() []*ScheduleRequest {
 	return nil
 }
 
+func (x *ScheduleList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type ScheduleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -194,7 +693,7 @@ type ScheduleResponse struct {
 
 func (x *ScheduleResponse) Reset() {
 	*x = ScheduleResponse{}
-	mi := &file_schedule_proto_msgTypes[3]
+	mi := &file_schedule_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -206,7 +705,7 @@ func (x *ScheduleResponse) String() string {
 func (*ScheduleResponse) ProtoMessage() {}
 
 func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[3]
+	mi := &file_schedule_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +718,7 @@ func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleResponse.ProtoReflect.Descriptor instead.
 func (*ScheduleResponse) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{3}
+	return file_schedule_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ScheduleResponse) GetMessage() string {
@@ -237,7 +736,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_schedule_proto_msgTypes[4]
+	mi := &file_schedule_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -249,7 +748,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[4]
+	mi := &file_schedule_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,31 +761,221 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{4}
+	return file_schedule_proto_rawDescGZIP(), []int{8}
+}
+
+// WatchRequest subscribes to schedule events. An empty types list means
+// every event type.
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []EventType            `protobuf:"varint,1,rep,packed,name=types,proto3,enum=schedule.EventType" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_schedule_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type ScheduleEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Type     EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=schedule.EventType" json:"type,omitempty"`
+	Schedule *ScheduleRequest       `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// time is when the event happened, in RFC 3339.
+	Time string `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// alert is the offset of the lead-time alert that fired, for fired
+	// events sent before datetime.
+	Alert string `protobuf:"bytes,4,opt,name=alert,proto3" json:"alert,omitempty"`
+	// user is the member who acknowledged, for acknowledged events.
+	User          string `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleEvent) Reset() {
+	*x = ScheduleEvent{}
+	mi := &file_schedule_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleEvent) ProtoMessage() {}
+
+func (x *ScheduleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleEvent.ProtoReflect.Descriptor instead.
+func (*ScheduleEvent) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ScheduleEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduleEvent) GetSchedule() *ScheduleRequest {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *ScheduleEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *ScheduleEvent) GetAlert() string {
+	if x != nil {
+		return x.Alert
+	}
+	return ""
+}
+
+func (x *ScheduleEvent) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
 }
 
 var File_schedule_proto protoreflect.FileDescriptor
 
 const file_schedule_proto_rawDesc = "" +
 	"\n" +
-	"\x0eschedule.proto\x12\bschedule\"y\n" +
+	"\x0eschedule.proto\x12\bschedule\"\xe0\x03\n" +
 	"\x0fScheduleRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
 	"\bdatetime\x18\x03 \x01(\tR\bdatetime\x12\x10\n" +
 	"\x03url\x18\x04 \x01(\tR\x03url\x12\x12\n" +
-	"\x04memo\x18\x05 \x01(\tR\x04memo\"\x1f\n" +
+	"\x04memo\x18\x05 \x01(\tR\x04memo\x12\x14\n" +
+	"\x05rrule\x18\x06 \x01(\tR\x05rrule\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12\x17\n" +
+	"\aack_due\x18\b \x01(\tR\x06ackDue\x12!\n" +
+	"\fnotify_count\x18\t \x01(\x05R\vnotifyCount\x12\x16\n" +
+	"\x06alerts\x18\n" +
+	" \x03(\tR\x06alerts\x12!\n" +
+	"\ffired_alerts\x18\v \x03(\tR\vfiredAlerts\x12\x1d\n" +
+	"\n" +
+	"next_alert\x18\f \x01(\tR\tnextAlert\x12\x0e\n" +
+	"\x02tz\x18\r \x01(\tR\x02tz\x12\x12\n" +
+	"\x04tags\x18\x0e \x03(\tR\x04tags\x12\x14\n" +
+	"\x05owner\x18\x0f \x01(\tR\x05owner\x12\x1c\n" +
+	"\tassignees\x18\x10 \x03(\tR\tassignees\x12\x19\n" +
+	"\backed_by\x18\x11 \x03(\tR\aackedBy\x12\x18\n" +
+	"\amembers\x18\x12 \x03(\tR\amembers\x12\x16\n" +
+	"\x06emails\x18\x13 \x03(\tR\x06emails\"\xf2\x01\n" +
+	"\x14ListSchedulesRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x14\n" +
+	"\x05regex\x18\x04 \x01(\tR\x05regex\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04mine\x18\t \x01(\bR\x04mine\x12\x12\n" +
+	"\x04team\x18\n" +
+	" \x01(\bR\x04team\"\x7f\n" +
+	"\x15UpdateScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
+	"\bschedule\x18\x02 \x01(\v2\x19.schedule.ScheduleRequestR\bschedule\x12\x1f\n" +
+	"\vupdate_mask\x18\x03 \x03(\tR\n" +
+	"updateMask\"\x1c\n" +
+	"\n" +
+	"ScheduleId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\rSnoozeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\tR\bduration\"\x1f\n" +
 	"\vScheduleIdx\x12\x10\n" +
-	"\x03idx\x18\x01 \x01(\x05R\x03idx\"G\n" +
+	"\x03idx\x18\x01 \x01(\x05R\x03idx\"o\n" +
 	"\fScheduleList\x127\n" +
-	"\tschedules\x18\x01 \x03(\v2\x19.schedule.ScheduleRequestR\tschedules\",\n" +
+	"\tschedules\x18\x01 \x03(\v2\x19.schedule.ScheduleRequestR\tschedules\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\",\n" +
 	"\x10ScheduleResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"\a\n" +
-	"\x05Empty2\xd0\x01\n" +
+	"\x05Empty\"9\n" +
+	"\fWatchRequest\x12)\n" +
+	"\x05types\x18\x01 \x03(\x0e2\x13.schedule.EventTypeR\x05types\"\xad\x01\n" +
+	"\rScheduleEvent\x12'\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x13.schedule.EventTypeR\x04type\x125\n" +
+	"\bschedule\x18\x02 \x01(\v2\x19.schedule.ScheduleRequestR\bschedule\x12\x12\n" +
+	"\x04time\x18\x03 \x01(\tR\x04time\x12\x14\n" +
+	"\x05alert\x18\x04 \x01(\tR\x05alert\x12\x12\n" +
+	"\x04user\x18\x05 \x01(\tR\x04user*\xcf\x01\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10EVENT_TYPE_ADDED\x10\x01\x12\x16\n" +
+	"\x12EVENT_TYPE_UPDATED\x10\x02\x12\x16\n" +
+	"\x12EVENT_TYPE_DELETED\x10\x03\x12\x14\n" +
+	"\x10EVENT_TYPE_FIRED\x10\x04\x12\x15\n" +
+	"\x11EVENT_TYPE_MISSED\x10\x05\x12\x16\n" +
+	"\x12EVENT_TYPE_SNOOZED\x10\x06\x12\x1b\n" +
+	"\x17EVENT_TYPE_ACKNOWLEDGED\x10\a2\x80\x05\n" +
 	"\tScheduler\x12D\n" +
-	"\vAddSchedule\x12\x19.schedule.ScheduleRequest\x1a\x1a.schedule.ScheduleResponse\x128\n" +
-	"\rListSchedules\x12\x0f.schedule.Empty\x1a\x16.schedule.ScheduleList\x12C\n" +
-	"\x0eDeleteSchedule\x12\x15.schedule.ScheduleIdx\x1a\x1a.schedule.ScheduleResponseB\rZ\v/schedulepbb\x06proto3"
+	"\vAddSchedule\x12\x19.schedule.ScheduleRequest\x1a\x1a.schedule.ScheduleResponse\x12G\n" +
+	"\rListSchedules\x12\x1e.schedule.ListSchedulesRequest\x1a\x16.schedule.ScheduleList\x12C\n" +
+	"\x0eDeleteSchedule\x12\x15.schedule.ScheduleIdx\x1a\x1a.schedule.ScheduleResponse\x12M\n" +
+	"\x0eUpdateSchedule\x12\x1f.schedule.UpdateScheduleRequest\x1a\x1a.schedule.ScheduleResponse\x12>\n" +
+	"\vGetSchedule\x12\x14.schedule.ScheduleId\x1a\x19.schedule.ScheduleRequest\x12F\n" +
+	"\x12DeleteScheduleById\x12\x14.schedule.ScheduleId\x1a\x1a.schedule.ScheduleResponse\x12@\n" +
+	"\vWatchEvents\x12\x16.schedule.WatchRequest\x1a\x17.schedule.ScheduleEvent0\x01\x12E\n" +
+	"\x0eSnoozeSchedule\x12\x17.schedule.SnoozeRequest\x1a\x1a.schedule.ScheduleResponse\x12?\n" +
+	"\vAckSchedule\x12\x14.schedule.ScheduleId\x1a\x1a.schedule.ScheduleResponseB\rZ\v/schedulepbb\x06proto3"
 
 var (
 	file_schedule_proto_rawDescOnce sync.Once
@@ -300,27 +989,51 @@ func file_schedule_proto_rawDescGZIP() []byte {
 	return file_schedule_proto_rawDescData
 }
 
-var file_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_schedule_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_schedule_proto_goTypes = []any{
-	(*ScheduleRequest)(nil),  // 0: schedule.ScheduleRequest
-	(*ScheduleIdx)(nil),      // 1: schedule.ScheduleIdx
-	(*ScheduleList)(nil),     // 2: schedule.ScheduleList
-	(*ScheduleResponse)(nil), // 3: schedule.ScheduleResponse
-	(*Empty)(nil),            // 4: schedule.Empty
+	(EventType)(0),                // 0: schedule.EventType
+	(*ScheduleRequest)(nil),       // 1: schedule.ScheduleRequest
+	(*ListSchedulesRequest)(nil),  // 2: schedule.ListSchedulesRequest
+	(*UpdateScheduleRequest)(nil), // 3: schedule.UpdateScheduleRequest
+	(*ScheduleId)(nil),            // 4: schedule.ScheduleId
+	(*SnoozeRequest)(nil),         // 5: schedule.SnoozeRequest
+	(*ScheduleIdx)(nil),           // 6: schedule.ScheduleIdx
+	(*ScheduleList)(nil),          // 7: schedule.ScheduleList
+	(*ScheduleResponse)(nil),      // 8: schedule.ScheduleResponse
+	(*Empty)(nil),                 // 9: schedule.Empty
+	(*WatchRequest)(nil),          // 10: schedule.WatchRequest
+	(*ScheduleEvent)(nil),         // 11: schedule.ScheduleEvent
 }
 var file_schedule_proto_depIdxs = []int32{
-	0, // 0: schedule.ScheduleList.schedules:type_name -> schedule.ScheduleRequest
-	0, // 1: schedule.Scheduler.AddSchedule:input_type -> schedule.ScheduleRequest
-	4, // 2: schedule.Scheduler.ListSchedules:input_type -> schedule.Empty
-	1, // 3: schedule.Scheduler.DeleteSchedule:input_type -> schedule.ScheduleIdx
-	3, // 4: schedule.Scheduler.AddSchedule:output_type -> schedule.ScheduleResponse
-	2, // 5: schedule.Scheduler.ListSchedules:output_type -> schedule.ScheduleList
-	3, // 6: schedule.Scheduler.DeleteSchedule:output_type -> schedule.ScheduleResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: schedule.UpdateScheduleRequest.schedule:type_name -> schedule.ScheduleRequest
+	1,  // 1: schedule.ScheduleList.schedules:type_name -> schedule.ScheduleRequest
+	0,  // 2: schedule.WatchRequest.types:type_name -> schedule.EventType
+	0,  // 3: schedule.ScheduleEvent.type:type_name -> schedule.EventType
+	1,  // 4: schedule.ScheduleEvent.schedule:type_name -> schedule.ScheduleRequest
+	1,  // 5: schedule.Scheduler.AddSchedule:input_type -> schedule.ScheduleRequest
+	2,  // 6: schedule.Scheduler.ListSchedules:input_type -> schedule.ListSchedulesRequest
+	6,  // 7: schedule.Scheduler.DeleteSchedule:input_type -> schedule.ScheduleIdx
+	3,  // 8: schedule.Scheduler.UpdateSchedule:input_type -> schedule.UpdateScheduleRequest
+	4,  // 9: schedule.Scheduler.GetSchedule:input_type -> schedule.ScheduleId
+	4,  // 10: schedule.Scheduler.DeleteScheduleById:input_type -> schedule.ScheduleId
+	10, // 11: schedule.Scheduler.WatchEvents:input_type -> schedule.WatchRequest
+	5,  // 12: schedule.Scheduler.SnoozeSchedule:input_type -> schedule.SnoozeRequest
+	4,  // 13: schedule.Scheduler.AckSchedule:input_type -> schedule.ScheduleId
+	8,  // 14: schedule.Scheduler.AddSchedule:output_type -> schedule.ScheduleResponse
+	7,  // 15: schedule.Scheduler.ListSchedules:output_type -> schedule.ScheduleList
+	8,  // 16: schedule.Scheduler.DeleteSchedule:output_type -> schedule.ScheduleResponse
+	8,  // 17: schedule.Scheduler.UpdateSchedule:output_type -> schedule.ScheduleResponse
+	1,  // 18: schedule.Scheduler.GetSchedule:output_type -> schedule.ScheduleRequest
+	8,  // 19: schedule.Scheduler.DeleteScheduleById:output_type -> schedule.ScheduleResponse
+	11, // 20: schedule.Scheduler.WatchEvents:output_type -> schedule.ScheduleEvent
+	8,  // 21: schedule.Scheduler.SnoozeSchedule:output_type -> schedule.ScheduleResponse
+	8,  // 22: schedule.Scheduler.AckSchedule:output_type -> schedule.ScheduleResponse
+	14, // [14:23] is the sub-list for method output_type
+	5,  // [5:14] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_schedule_proto_init() }
@@ -333,13 +1046,14 @@ func file_schedule_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schedule_proto_rawDesc), len(file_schedule_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      1,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_schedule_proto_goTypes,
 		DependencyIndexes: file_schedule_proto_depIdxs,
+		EnumInfos:         file_schedule_proto_enumTypes,
 		MessageInfos:      file_schedule_proto_msgTypes,
 	}.Build()
 	File_schedule_proto = out.File
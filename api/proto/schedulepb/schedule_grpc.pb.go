@@ -19,9 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Scheduler_AddSchedule_FullMethodName    = "/schedule.Scheduler/AddSchedule"
-	Scheduler_ListSchedules_FullMethodName  = "/schedule.Scheduler/ListSchedules"
-	Scheduler_DeleteSchedule_FullMethodName = "/schedule.Scheduler/DeleteSchedule"
+	Scheduler_AddSchedule_FullMethodName        = "/schedule.Scheduler/AddSchedule"
+	Scheduler_ListSchedules_FullMethodName      = "/schedule.Scheduler/ListSchedules"
+	Scheduler_DeleteSchedule_FullMethodName     = "/schedule.Scheduler/DeleteSchedule"
+	Scheduler_UpdateSchedule_FullMethodName     = "/schedule.Scheduler/UpdateSchedule"
+	Scheduler_GetSchedule_FullMethodName        = "/schedule.Scheduler/GetSchedule"
+	Scheduler_DeleteScheduleById_FullMethodName = "/schedule.Scheduler/DeleteScheduleById"
+	Scheduler_WatchEvents_FullMethodName        = "/schedule.Scheduler/WatchEvents"
+	Scheduler_SnoozeSchedule_FullMethodName     = "/schedule.Scheduler/SnoozeSchedule"
+	Scheduler_AckSchedule_FullMethodName        = "/schedule.Scheduler/AckSchedule"
 )
 
 // SchedulerClient is the client API for Scheduler service.
@@ -29,8 +35,14 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SchedulerClient interface {
 	AddSchedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
-	ListSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScheduleList, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ScheduleList, error)
 	DeleteSchedule(ctx context.Context, in *ScheduleIdx, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	GetSchedule(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*ScheduleRequest, error)
+	DeleteScheduleById(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	WatchEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScheduleEvent], error)
+	SnoozeSchedule(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	AckSchedule(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*ScheduleResponse, error)
 }
 
 type schedulerClient struct {
@@ -51,7 +63,7 @@ func (c *schedulerClient) AddSchedule(ctx context.Context, in *ScheduleRequest,
 	return out, nil
 }
 
-func (c *schedulerClient) ListSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScheduleList, error) {
+func (c *schedulerClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ScheduleList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ScheduleList)
 	err := c.cc.Invoke(ctx, Scheduler_ListSchedules_FullMethodName, in, out, cOpts...)
@@ -71,13 +83,88 @@ func (c *schedulerClient) DeleteSchedule(ctx context.Context, in *ScheduleIdx, o
 	return out, nil
 }
 
+func (c *schedulerClient) UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, Scheduler_UpdateSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetSchedule(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*ScheduleRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleRequest)
+	err := c.cc.Invoke(ctx, Scheduler_GetSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) DeleteScheduleById(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, Scheduler_DeleteScheduleById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) WatchEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScheduleEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Scheduler_ServiceDesc.Streams[0], Scheduler_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, ScheduleEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Scheduler_WatchEventsClient = grpc.ServerStreamingClient[ScheduleEvent]
+
+func (c *schedulerClient) SnoozeSchedule(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, Scheduler_SnoozeSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) AckSchedule(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, Scheduler_AckSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SchedulerServer is the server API for Scheduler service.
 // All implementations must embed UnimplementedSchedulerServer
 // for forward compatibility.
 type SchedulerServer interface {
 	AddSchedule(context.Context, *ScheduleRequest) (*ScheduleResponse, error)
-	ListSchedules(context.Context, *Empty) (*ScheduleList, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ScheduleList, error)
 	DeleteSchedule(context.Context, *ScheduleIdx) (*ScheduleResponse, error)
+	UpdateSchedule(context.Context, *UpdateScheduleRequest) (*ScheduleResponse, error)
+	GetSchedule(context.Context, *ScheduleId) (*ScheduleRequest, error)
+	DeleteScheduleById(context.Context, *ScheduleId) (*ScheduleResponse, error)
+	WatchEvents(*WatchRequest, grpc.ServerStreamingServer[ScheduleEvent]) error
+	SnoozeSchedule(context.Context, *SnoozeRequest) (*ScheduleResponse, error)
+	AckSchedule(context.Context, *ScheduleId) (*ScheduleResponse, error)
 	mustEmbedUnimplementedSchedulerServer()
 }
 
@@ -91,12 +178,30 @@ type UnimplementedSchedulerServer struct{}
 func (UnimplementedSchedulerServer) AddSchedule(context.Context, *ScheduleRequest) (*ScheduleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSchedule not implemented")
 }
-func (UnimplementedSchedulerServer) ListSchedules(context.Context, *Empty) (*ScheduleList, error) {
+func (UnimplementedSchedulerServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ScheduleList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
 }
 func (UnimplementedSchedulerServer) DeleteSchedule(context.Context, *ScheduleIdx) (*ScheduleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSchedule not implemented")
 }
+func (UnimplementedSchedulerServer) UpdateSchedule(context.Context, *UpdateScheduleRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSchedule not implemented")
+}
+func (UnimplementedSchedulerServer) GetSchedule(context.Context, *ScheduleId) (*ScheduleRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+func (UnimplementedSchedulerServer) DeleteScheduleById(context.Context, *ScheduleId) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteScheduleById not implemented")
+}
+func (UnimplementedSchedulerServer) WatchEvents(*WatchRequest, grpc.ServerStreamingServer[ScheduleEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedSchedulerServer) SnoozeSchedule(context.Context, *SnoozeRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeSchedule not implemented")
+}
+func (UnimplementedSchedulerServer) AckSchedule(context.Context, *ScheduleId) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckSchedule not implemented")
+}
 func (UnimplementedSchedulerServer) mustEmbedUnimplementedSchedulerServer() {}
 func (UnimplementedSchedulerServer) testEmbeddedByValue()                   {}
 
@@ -137,7 +242,7 @@ func _Scheduler_AddSchedule_Handler(srv interface{}, ctx context.Context, dec fu
 }
 
 func _Scheduler_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListSchedulesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -149,7 +254,7 @@ func _Scheduler_ListSchedules_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: Scheduler_ListSchedules_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServer).ListSchedules(ctx, req.(*Empty))
+		return srv.(SchedulerServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -172,6 +277,107 @@ func _Scheduler_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Scheduler_UpdateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).UpdateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_UpdateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).UpdateSchedule(ctx, req.(*UpdateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetSchedule(ctx, req.(*ScheduleId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_DeleteScheduleById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).DeleteScheduleById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_DeleteScheduleById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).DeleteScheduleById(ctx, req.(*ScheduleId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SchedulerServer).WatchEvents(m, &grpc.GenericServerStream[WatchRequest, ScheduleEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Scheduler_WatchEventsServer = grpc.ServerStreamingServer[ScheduleEvent]
+
+func _Scheduler_SnoozeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).SnoozeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_SnoozeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).SnoozeSchedule(ctx, req.(*SnoozeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_AckSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).AckSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_AckSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).AckSchedule(ctx, req.(*ScheduleId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Scheduler_ServiceDesc is the grpc.ServiceDesc for Scheduler service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,7 +397,33 @@ var Scheduler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSchedule",
 			Handler:    _Scheduler_DeleteSchedule_Handler,
 		},
+		{
+			MethodName: "UpdateSchedule",
+			Handler:    _Scheduler_UpdateSchedule_Handler,
+		},
+		{
+			MethodName: "GetSchedule",
+			Handler:    _Scheduler_GetSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteScheduleById",
+			Handler:    _Scheduler_DeleteScheduleById_Handler,
+		},
+		{
+			MethodName: "SnoozeSchedule",
+			Handler:    _Scheduler_SnoozeSchedule_Handler,
+		},
+		{
+			MethodName: "AckSchedule",
+			Handler:    _Scheduler_AckSchedule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Scheduler_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "schedule.proto",
 }
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/recur"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"github.com/je0ng3/remindme-cli/internal/zone"
+)
+
+// occurrence is one due time of a schedule; recurring schedules have one
+// per repetition.
+type occurrence struct {
+	at  time.Time
+	sch *schedulepb.ScheduleRequest
+}
+
+// occurrenceRow is an occurrence in the --output formats.
+type occurrenceRow struct {
+	At          string `json:"at" yaml:"at"`
+	scheduleRow `yaml:",inline"`
+}
+
+func (r occurrenceRow) header() []string {
+	return append([]string{"at"}, r.scheduleRow.header()[1:]...)
+}
+
+func (r occurrenceRow) fields() []string {
+	return append([]string{r.At}, r.scheduleRow.fields()[1:]...)
+}
+
+// occurrences lists every due time in [from, to), expanding recurring
+// schedules in their own zone, in chronological order.
+func occurrences(client schedulepb.SchedulerClient, from, to time.Time) ([]occurrence, error) {
+	var out []occurrence
+	req := &schedulepb.ListSchedulesRequest{To: to.Format(time.RFC3339), Sort: "datetime"}
+	for {
+		res, err := client.ListSchedules(context.Background(), req)
+		if err != nil {
+			return nil, err
+		}
+		for _, sch := range res.Schedules {
+			t, err := watcher.ParseDatetime(sch.Datetime)
+			if err != nil {
+				continue
+			}
+			var rule *recur.Rule
+			if sch.Rrule != "" {
+				rule, _ = recur.Parse(sch.Rrule)
+			}
+			if rule == nil {
+				if !t.Before(from) {
+					out = append(out, occurrence{t, sch})
+				}
+				continue
+			}
+			if loc, err := zone.Load(sch.Tz); err == nil {
+				t = t.In(loc)
+			}
+			// Datetime is the next due time and fires even when the rule
+			// would not generate it, e.g. a Sunday start with BYDAY=MO.
+			if !t.Before(from) {
+				out = append(out, occurrence{t, sch})
+			}
+			for _, at := range rule.Between(t, from, to) {
+				if !at.Equal(t) {
+					out = append(out, occurrence{at, sch})
+				}
+			}
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		req.PageToken = res.NextPageToken
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].at.Before(out[j].at) })
+	return out, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// relative renders d like "in 2h 15m" or "3d 4h ago", to the minute.
+func relative(d time.Duration) string {
+	past := d < 0
+	if past {
+		d = -d
+	}
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "now"
+	}
+
+	var parts []string
+	for _, u := range []struct {
+		unit   time.Duration
+		suffix string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}} {
+		if n := d / u.unit; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.suffix))
+			d -= n * u.unit
+		}
+	}
+	// Two units are enough to read at a glance.
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	if past {
+		return strings.Join(parts, " ") + " ago"
+	}
+	return "in " + strings.Join(parts, " ")
+}
+
+func dayLabel(day, today time.Time) string {
+	label := fmt.Sprintf("%s (%s)", day.Format("2006-01-02"), weekdayLabels[day.Weekday()])
+	switch {
+	case day.Equal(today):
+		return "오늘 " + label
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return "내일 " + label
+	}
+	return label
+}
+
+func runAgendaCommand(client schedulepb.SchedulerClient, args []string) {
+	fs := flag.NewFlagSet("agenda", flag.ExitOnError)
+	days := fs.Int("days", 0, "오늘부터 볼 일수 (today=1, week=7)")
+	out := addOutputFlags(fs)
+	fs.Parse(args)
+	p, err := out.printer(os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	span := 1
+	switch fs.Arg(0) {
+	case "", "today":
+	case "week":
+		span = 7
+	default:
+		fmt.Println("사용법: remindme agenda [today|week] [--days n]")
+		os.Exit(2)
+	}
+	if *days > 0 {
+		span = *days
+	}
+
+	now := time.Now()
+	today := startOfDay(now)
+	end := today.AddDate(0, 0, span)
+	occs, err := occurrences(client, today, end)
+	if err != nil {
+		printError("일정 목록 불러오기 실패", err)
+		os.Exit(1)
+	}
+
+	if !p.table() {
+		recs := make([]record, 0, len(occs))
+		for _, o := range occs {
+			recs = append(recs, occurrenceRow{At: o.at.Format(time.RFC3339), scheduleRow: newScheduleRow(0, o.sch)})
+		}
+		if err := p.list(recs); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(occs) == 0 {
+		fmt.Println("예정된 일정이 없습니다.")
+		return
+	}
+	var day time.Time
+	for _, o := range occs {
+		at := o.at.Local()
+		if d := startOfDay(at); !d.Equal(day) {
+			if !day.IsZero() {
+				fmt.Println()
+			}
+			day = d
+			fmt.Println(dayLabel(day, today))
+		}
+		line := fmt.Sprintf("  %s  %-12s  %s  %s", at.Format("15:04"), relative(at.Sub(now)), shortID(o.sch.Id), o.sch.Title)
+		if o.sch.Rrule != "" {
+			line += " (반복)"
+		}
+		if label := stateLabel(o.sch); label != "" && !at.After(now) {
+			line += " [" + label + "]"
+		}
+		fmt.Println(line)
+	}
+}
+
+func runCalCommand(client schedulepb.SchedulerClient, args []string) {
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	if len(args) > 0 {
+		t, err := time.ParseInLocation("2006-01", args[0], time.Local)
+		if err != nil {
+			fmt.Println("사용법: remindme cal [YYYY-MM]")
+			os.Exit(2)
+		}
+		month = t
+	}
+	next := month.AddDate(0, 1, 0)
+
+	occs, err := occurrences(client, month, next)
+	if err != nil {
+		printError("일정 목록 불러오기 실패", err)
+		os.Exit(1)
+	}
+	printCal(os.Stdout, month, startOfDay(now), occs)
+}
+
+// printCal writes the month grid starting at month, marking the days with
+// occurrences and today.
+func printCal(w io.Writer, month, today time.Time, occs []occurrence) {
+	next := month.AddDate(0, 1, 0)
+	counts := map[int]int{}
+	for _, o := range occs {
+		counts[o.at.Local().Day()]++
+	}
+
+	// Each cell is four columns wide: the day, then a marker. Hangul takes
+	// two columns, so the 11-column title is indented by (28-11)/2.
+	fmt.Fprintln(w, strings.Repeat(" ", 8)+month.Format("2006년 01월"))
+	for _, wd := range weekdayLabels {
+		fmt.Fprintf(w, " %s ", wd)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprint(w, strings.Repeat("    ", int(month.Weekday())))
+	for d := month; d.Before(next); d = d.AddDate(0, 0, 1) {
+		mark := " "
+		switch isToday, has := d.Equal(today), counts[d.Day()] > 0; {
+		case isToday && has:
+			mark = "@"
+		case isToday:
+			mark = "<"
+		case has:
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%3d%s", d.Day(), mark)
+		if d.Weekday() == time.Saturday {
+			fmt.Fprintln(w)
+		}
+	}
+	if next.Weekday() != time.Sunday {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "\n* 일정 있음  < 오늘  @ 오늘 일정 있음  (이번 달 %d개)\n", len(occs))
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"google.golang.org/grpc"
+)
+
+// pagedLister serves ListSchedules from fixed pages, ignoring filters.
+type pagedLister struct {
+	schedulepb.SchedulerClient
+	pages [][]*schedulepb.ScheduleRequest
+}
+
+func (l pagedLister) ListSchedules(_ context.Context, req *schedulepb.ListSchedulesRequest, _ ...grpc.CallOption) (*schedulepb.ScheduleList, error) {
+	i := 0
+	if req.PageToken != "" {
+		i, _ = strconv.Atoi(req.PageToken)
+	}
+	res := &schedulepb.ScheduleList{Schedules: l.pages[i]}
+	if i+1 < len(l.pages) {
+		res.NextPageToken = strconv.Itoa(i + 1)
+	}
+	return res, nil
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+// dstSchedules straddle the start of daylight saving time in New York on
+// Sunday 2025-03-09, when 02:00 EST jumps to 03:00 EDT.
+var dstSchedules = [][]*schedulepb.ScheduleRequest{{
+	{Id: "daily", Title: "Daily", Datetime: "2025-03-01T09:00:00-05:00", Tz: "America/New_York", Rrule: "FREQ=DAILY"},
+	{Id: "gap", Title: "Gap", Datetime: "2025-03-01T02:30:00-05:00", Tz: "America/New_York", Rrule: "FREQ=DAILY;COUNT=10"},
+	{Id: "seoul", Title: "Seoul", Datetime: "2025-03-03T23:00:00+09:00", Tz: "Asia/Seoul", Rrule: "FREQ=WEEKLY;BYDAY=MO,WE"},
+}, {
+	{Id: "before", Title: "Before", Datetime: "2025-03-06T12:00:00Z", Tz: "UTC"},
+	{Id: "once", Title: "Once", Datetime: "2025-03-10T12:00:00Z", Tz: "UTC"},
+	{Id: "starts", Title: "Starts", Datetime: "2025-03-12T18:00:00-04:00", Tz: "America/New_York", Rrule: "daily"},
+	{Id: "broken", Title: "Broken", Datetime: "someday"},
+}}
+
+func TestOccurrences_Week(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	from := time.Date(2025, 3, 7, 0, 0, 0, 0, ny)
+	occs, err := occurrences(pagedLister{pages: dstSchedules}, from, from.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, o := range occs {
+		got = append(got, o.sch.Id+" "+o.at.Format(time.RFC3339))
+	}
+	want := []string{
+		"gap 2025-03-07T02:30:00-05:00",
+		"daily 2025-03-07T09:00:00-05:00",
+		"gap 2025-03-08T02:30:00-05:00",
+		"daily 2025-03-08T09:00:00-05:00",
+		// 02:30 does not exist on the 9th and moves forward by the gap.
+		"gap 2025-03-09T03:30:00-04:00",
+		// The wall time stays at 09:00, an hour earlier in UTC.
+		"daily 2025-03-09T09:00:00-04:00",
+		"gap 2025-03-10T02:30:00-04:00",
+		"once 2025-03-10T12:00:00Z",
+		"daily 2025-03-10T09:00:00-04:00",
+		// Monday 23:00 in Seoul is Monday 10:00 in New York.
+		"seoul 2025-03-10T23:00:00+09:00",
+		"daily 2025-03-11T09:00:00-04:00",
+		"daily 2025-03-12T09:00:00-04:00",
+		"seoul 2025-03-12T23:00:00+09:00",
+		"starts 2025-03-12T18:00:00-04:00",
+		"daily 2025-03-13T09:00:00-04:00",
+		"starts 2025-03-13T18:00:00-04:00",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("occurrences\n got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestPrintCal_DSTMonth(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	local := time.Local
+	time.Local = ny
+	defer func() { time.Local = local }()
+
+	// Sundays 04:30 UTC are Saturday 23:30 in New York until DST starts,
+	// and Sunday 00:30 after.
+	lister := pagedLister{pages: [][]*schedulepb.ScheduleRequest{{
+		{Id: "weekly", Title: "Weekly", Datetime: "2025-03-02T04:30:00Z", Tz: "UTC", Rrule: "FREQ=WEEKLY;BYDAY=SU"},
+	}}}
+	month := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	occs, err := occurrences(lister, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	printCal(&out, month, time.Date(2025, 3, 9, 0, 0, 0, 0, time.Local), occs)
+
+	want := "        2025년 03월\n" +
+		" 일  월  화  수  목  금  토 \n" +
+		"                          1*\n" +
+		"  2   3   4   5   6   7   8*\n" +
+		"  9< 10  11  12  13  14  15 \n" +
+		" 16* 17  18  19  20  21  22 \n" +
+		" 23* 24  25  26  27  28  29 \n" +
+		" 30* 31 \n" +
+		"\n* 일정 있음  < 오늘  @ 오늘 일정 있음  (이번 달 5개)\n"
+	if out.String() != want {
+		t.Errorf("cal\n got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPrintCal_Marks(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	// February 2026 starts on a Sunday and ends on a Saturday.
+	month := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	occs := []occurrence{
+		{at: time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)},
+		{at: time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)},
+		{at: time.Date(2026, 2, 10, 18, 0, 0, 0, time.UTC)},
+	}
+	var out bytes.Buffer
+	printCal(&out, month, time.Date(2026, 2, 5, 0, 0, 0, 0, time.Local), occs)
+	want := "        2026년 02월\n" +
+		" 일  월  화  수  목  금  토 \n" +
+		"  1   2   3*  4   5<  6   7 \n" +
+		"  8   9  10* 11  12  13  14 \n" +
+		" 15  16  17  18  19  20  21 \n" +
+		" 22  23  24  25  26  27  28 \n" +
+		"\n* 일정 있음  < 오늘  @ 오늘 일정 있음  (이번 달 3개)\n"
+	if out.String() != want {
+		t.Errorf("cal\n got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/certs"
+	"github.com/je0ng3/remindme-cli/internal/config"
+	"github.com/je0ng3/remindme-cli/internal/unixsock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/local"
+)
+
+// dialOptions picks the transport for the target: the local socket is
+// private already, TCP uses TLS once a CA is configured. The saved token
+// is sent only over those two, never in cleartext.
+func dialOptions(cfg config.Client) ([]grpc.DialOption, error) {
+	token, err := readToken(cfg.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []grpc.DialOption
+	secure := true
+	switch {
+	case strings.HasPrefix(cfg.Target(), unixsock.Scheme):
+		opts = append(opts, grpc.WithTransportCredentials(local.NewCredentials()))
+	case cfg.CA != "":
+		tlsConfig, err := certs.ClientConfig(cfg.CA, cfg.Cert, cfg.Key)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	case cfg.Cert != "":
+		return nil, fmt.Errorf("클라이언트 인증서를 쓰려면 --ca도 지정하세요")
+	default:
+		opts = append(opts, grpc.WithInsecure())
+		secure = false
+	}
+
+	if token != "" {
+		if !secure {
+			fmt.Fprintln(os.Stderr, "경고: 암호화되지 않은 연결이라 토큰을 보내지 않습니다. --ca로 TLS를 켜세요.")
+		} else {
+			opts = append(opts, grpc.WithPerRPCCredentials(auth.Token(token)))
+		}
+	}
+	return opts, nil
+}
+
+// readToken reads the credential file. A missing file means no token.
+func readToken(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func runLoginCommand(cfg config.Client, args []string) {
+	if cfg.Token == "" {
+		fmt.Println("토큰 파일 경로를 정할 수 없습니다. --token-file을 지정하세요.")
+		os.Exit(1)
+	}
+	var token string
+	if len(args) > 0 {
+		token = args[0]
+	} else {
+		fmt.Print("토큰: ")
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		token = line
+	}
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, auth.TokenPrefix) {
+		fmt.Printf("토큰은 %s로 시작해야 합니다. 서버 관리자에게 remindserver user add로 발급받으세요.\n", auth.TokenPrefix)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Token), 0o700); err != nil {
+		fmt.Println("토큰 저장 실패:", err)
+		os.Exit(1)
+	}
+	err := os.WriteFile(cfg.Token, []byte(token+"\n"), 0o600)
+	if err == nil {
+		// WriteFile keeps the mode of a file that already exists.
+		err = os.Chmod(cfg.Token, 0o600)
+	}
+	if err != nil {
+		fmt.Println("토큰 저장 실패:", err)
+		os.Exit(1)
+	}
+	fmt.Println("토큰을 저장했습니다:", cfg.Token)
+}
+
+func runLogoutCommand(cfg config.Client) {
+	if err := os.Remove(cfg.Token); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fmt.Println("토큰 삭제 실패:", err)
+		os.Exit(1)
+	}
+	fmt.Println("토큰을 삭제했습니다.")
+}
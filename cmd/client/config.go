@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/je0ng3/remindme-cli/internal/config"
+)
+
+// runConfigCommand prints the effective client settings and where each
+// came from, followed by the server settings the same file and
+// environment would give a server started on this machine.
+func runConfigCommand(path string, cfg config.Client, sources config.Sources) {
+	fmt.Println("설정 파일:", path)
+	if _, err := os.Stat(path); err != nil {
+		fmt.Println("  (파일 없음)")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nclient\t\t")
+	for _, set := range config.Settings(cfg, sources) {
+		fmt.Fprintf(w, "  %s\t%s\t(%s)\n", set.Key, set.Value, set.Source)
+	}
+
+	w.Flush()
+	fmt.Println("  연결 대상:", cfg.Target())
+
+	srv, srvSources, err := config.LoadServer(path)
+	if err != nil {
+		w.Flush()
+		fmt.Println("서버 설정 오류:", err)
+		return
+	}
+	fmt.Fprintln(w, "\nserver\t\t")
+	for _, set := range config.Settings(srv, srvSources) {
+		fmt.Fprintf(w, "  %s\t%s\t(%s)\n", set.Key, set.Value, set.Source)
+	}
+	w.Flush()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/when"
+)
+
+func runListCommand(client schedulepb.SchedulerClient, args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	from := fs.String("from", "", "이 시간 이후의 일정만 (예: now, tomorrow, \"2025-07-01 00:00\")")
+	to := fs.String("to", "", "이 시간 이전의 일정만 (예: \"in 7d\", \"다음주 월요일 0시\")")
+	grep := fs.String("grep", "", "제목 또는 메모에 포함된 문자열 (대소문자 무시)")
+	regex := fs.String("regex", "", "제목 또는 메모에 맞는 정규식 (RE2)")
+	tags := fs.String("tag", "", "모두 가진 태그만, 쉼표로 구분")
+	sort := fs.String("sort", "", "정렬 기준: added | datetime | title (앞에 - 를 붙이면 역순)")
+	limit := fs.Int("limit", 0, "한 번에 보여줄 개수 (0은 전부)")
+	page := fs.String("page", "", "이전 출력에 표시된 다음 페이지 토큰")
+	mine := fs.Bool("mine", false, "내가 만들었거나 나에게 지정된 일정만")
+	team := fs.Bool("team", false, "담당자가 지정된 팀 일정만, 확인한 사람과 아직 확인하지 않은 사람을 함께 표시")
+	out := addOutputFlags(fs)
+	fs.Parse(args)
+	p, err := out.printer(os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	req := &schedulepb.ListSchedulesRequest{
+		Query:     *grep,
+		Regex:     *regex,
+		Tags:      splitList(*tags),
+		Sort:      *sort,
+		PageSize:  int32(*limit),
+		PageToken: *page,
+		Mine:      *mine,
+		Team:      *team,
+	}
+	for _, b := range []struct {
+		value string
+		dst   *string
+	}{{*from, &req.From}, {*to, &req.To}} {
+		if b.value == "" {
+			continue
+		}
+		t, err := when.Parse(b.value, time.Now())
+		if err != nil {
+			fmt.Println("날짜를 이해하지 못했습니다:", err)
+			os.Exit(2)
+		}
+		*b.dst = t.Format(time.RFC3339)
+	}
+
+	res, err := client.ListSchedules(context.Background(), req)
+	if err != nil {
+		printError("일정 목록 불러오기 실패", err)
+		os.Exit(1)
+	}
+
+	// List indexes address the unfiltered list, so they are only shown
+	// when the output is that list.
+	indexed := req.From == "" && req.To == "" && req.Query == "" && req.Regex == "" &&
+		len(req.Tags) == 0 && req.Sort == "" && req.PageSize == 0 && req.PageToken == "" &&
+		!req.Mine && !req.Team
+	index := func(i int) int {
+		if indexed {
+			return i + 1
+		}
+		return 0
+	}
+	if res.NextPageToken != "" {
+		defer fmt.Fprintln(os.Stderr, "다음 페이지: --page", res.NextPageToken)
+	}
+
+	if !p.table() {
+		recs := make([]record, 0, len(res.Schedules))
+		for i, sch := range res.Schedules {
+			recs = append(recs, newScheduleRow(index(i), sch))
+		}
+		if err := p.list(recs); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(res.Schedules) == 0 {
+		fmt.Println("등록된 일정이 없습니다.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if req.Team {
+		fmt.Fprintln(w, "ID\tTitle\tNext\tOwner\tAssignees\tState\tAcked\tPending")
+		for _, sch := range res.Schedules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", shortID(sch.Id), sch.Title, localTime(sch.Datetime),
+				sch.Owner, strings.Join(sch.Assignees, ","), stateLabel(sch), strings.Join(sch.AckedBy, ","),
+				strings.Join(pending(sch), ","))
+		}
+		w.Flush()
+		return
+	}
+	fmt.Fprintln(w, "No\tID\tTitle\tNext\tTZ\tAlerts\tNext alert\tRepeat\tTags\tState\tURL\tMemo")
+	for i, sch := range res.Schedules {
+		no := "-"
+		if n := index(i); n > 0 {
+			no = fmt.Sprint(n)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", no, shortID(sch.Id), sch.Title, localTime(sch.Datetime),
+			sch.Tz, strings.Join(sch.Alerts, ","), localTime(sch.NextAlert), sch.Rrule, strings.Join(sch.Tags, ","),
+			stateLabel(sch), sch.Url, sch.Memo)
+	}
+	w.Flush()
+}
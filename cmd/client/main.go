@@ -1,27 +1,89 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
 	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/config"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"github.com/je0ng3/remindme-cli/internal/when"
+	"github.com/je0ng3/remindme-cli/internal/zone"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const usage = "사용법: remindme [--server host:port] [--ca ca.pem --cert me.pem --key me-key.pem] add [--title ... --at ... | --from -] | list [--from ... --to ... --grep ... --sort ... --limit n] | agenda [today|week] | cal [YYYY-MM] | edit [index|id] | delete [index|id] | snooze [index|id] [duration] | done [index|id] | watch [--type ...] [--exec cmd] | config | login [token] | logout"
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("사용법: remindme add | list | delete [index]")
-		return 
+	configPath := config.PathFromArgs(os.Args[1:])
+	cfg, sources, err := config.LoadClient(configPath)
+	if err != nil {
+		log.Fatalf("설정 파일 오류: %v", err)
+	}
+	flag.String("config", configPath, "설정 파일 (환경 변수 REMINDME_CONFIG)")
+	flag.StringVar(&cfg.Server, "server", cfg.Server, "서버 주소 (환경 변수 REMINDME_SERVER)")
+	flag.StringVar(&cfg.TZ, "tz", cfg.TZ, "기본 시간대 (환경 변수 REMINDME_TZ, 기본값은 현재 시스템 시간대)")
+	flag.StringVar(&cfg.CA, "ca", cfg.CA, "서버 인증서를 확인할 CA 번들, system이면 시스템 인증서 (지정하면 TLS로 연결)")
+	flag.StringVar(&cfg.Cert, "cert", cfg.Cert, "서버에 제시할 클라이언트 인증서 (mTLS)")
+	flag.StringVar(&cfg.Key, "key", cfg.Key, "클라이언트 인증서의 키")
+	flag.StringVar(&cfg.Token, "token-file", cfg.Token, "login으로 저장한 토큰 파일")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	sources.MarkFlags(flag.CommandLine)
+
+	if cfg.TZ != "" {
+		loc, err := zone.Load(cfg.TZ)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Templates and displayed times use the local zone.
+		time.Local = loc
+		os.Setenv("TZ", cfg.TZ)
+	}
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Println(usage)
+		return
+	}
+	if args[0] == "config" {
+		runConfigCommand(configPath, cfg, sources)
+		return
+	}
+
+	switch args[0] {
+	case "login":
+		runLoginCommand(cfg, args[1:])
+		return
+	case "logout":
+		runLogoutCommand(cfg)
+		return
 	}
 
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	opts, err := dialOptions(cfg)
+	if err != nil {
+		log.Fatalf("연결 설정 오류: %v", err)
+	}
+	conn, err := grpc.Dial(cfg.Target(), opts...)
 	if err != nil {
 		log.Fatalf("failed to connect: %v", err)
 	}
@@ -29,37 +91,273 @@ func main() {
 
 	client := schedulepb.NewSchedulerClient(conn)
 
-	switch os.Args[1] {
+	switch args[0] {
 	case "add":
-		runAddCommand(client)
+		runAddCommand(client, args[1:])
 	case "list":
-		runListCommand(client)
+		runListCommand(client, args[1:])
+	case "agenda":
+		runAgendaCommand(client, args[1:])
+	case "cal":
+		runCalCommand(client, args[1:])
 	case "delete":
-		if len(os.Args) < 3 {
-			fmt.Println("삭제할 인덱스를 입력하세요.")
+		if len(args) < 2 {
+			fmt.Println("삭제할 인덱스 또는 ID를 입력하세요.")
+			return
+		}
+		runDeleteCommand(client, args[1])
+	case "snooze":
+		if len(args) < 3 {
+			fmt.Println("사용법: remindme snooze [index|id] [duration] (예: 10m, 1h)")
+			return
+		}
+		runSnoozeCommand(client, args[1], args[2])
+	case "done":
+		if len(args) < 2 {
+			fmt.Println("확인할 인덱스 또는 ID를 입력하세요.")
+			return
+		}
+		runDoneCommand(client, args[1])
+	case "watch":
+		runWatchCommand(client, args[1:])
+	case "edit":
+		if len(args) < 2 {
+			fmt.Println("수정할 인덱스 또는 ID를 입력하세요.")
+			return
 		}
-		runDeleteCommand(client, os.Args[2])
+		runEditCommand(client, args[1])
 	default:
-		fmt.Println("사용법: remindme add | list | delete [index]")
+		fmt.Println(usage)
 	}
 }
 
-func runAddCommand(client schedulepb.SchedulerClient) {
-	tmpfile, err := os.CreateTemp("", "remindme_*.txt")
+func runAddCommand(client schedulepb.SchedulerClient, args []string) {
+	req, interactive, err := addRequest(args, flag.ExitOnError)
+	if interactive {
+		req, err = editSchedule(&schedulepb.ScheduleRequest{Datetime: "2003-03-01 07:30"})
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if req.Title == "" || req.Datetime == "" {
+		fmt.Println("Title과 Datetime은 필수입니다.")
+		os.Exit(1)
+	}
+	if !confirmDatetime(req, interactive) {
+		os.Exit(1)
+	}
+
+	res, err := client.AddSchedule(context.Background(), req)
+	if err != nil {
+		printError("등록 실패", err)
+		os.Exit(1)
+	}
+	fmt.Println("일정 추가됨:", res.Message)
+}
+
+// addRequest builds the schedule to add from the flags in args and the
+// --from input. Without any flag it reports interactive instead, and the
+// editor is used.
+func addRequest(args []string, handling flag.ErrorHandling) (req *schedulepb.ScheduleRequest, interactive bool, err error) {
+	fs := flag.NewFlagSet("add", handling)
+	title := fs.String("title", "", "일정 제목")
+	at := fs.String("at", "", "일정 시간 (예: \"2025-07-22 18:00\", \"tomorrow 9am\", \"내일 오후 3시\")")
+	tz := fs.String("tz", "", "시간대 (IANA 이름, 기본값은 현재 시스템 시간대)")
+	url := fs.String("url", "", "알림 클릭 시 열 url")
+	memo := fs.String("memo", "", "메모")
+	repeat := fs.String("repeat", "", "반복 규칙 (daily | weekly | monthly | yearly 또는 RRULE)")
+	alerts := fs.String("alerts", "", "미리 알림 오프셋, 쉼표로 구분 (예: -1d,-30m,0)")
+	tags := fs.String("tags", "", "태그, 쉼표로 구분")
+	assign := fs.String("assign", "", "알림을 받을 사용자 또는 @그룹, 쉼표로 구분 (팀 알림)")
+	email := fs.String("email", "", "알림 메일을 받을 주소, 쉼표로 구분 (smtp 알림)")
+	from := fs.String("from", "", "템플릿 또는 JSON을 읽을 파일 (- 는 표준 입력)")
+	if err := fs.Parse(args); err != nil {
+		return nil, false, err
+	}
+
+	if fs.NFlag() == 0 {
+		return nil, true, nil
+	}
+	req = &schedulepb.ScheduleRequest{}
+	if *from != "" {
+		if req, err = readSchedule(*from); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Flags override what --from provided.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			req.Title = *title
+		case "at":
+			req.Datetime = *at
+		case "tz":
+			req.Tz = *tz
+		case "url":
+			req.Url = *url
+		case "memo":
+			req.Memo = *memo
+		case "repeat":
+			req.Rrule = *repeat
+		case "alerts":
+			req.Alerts = splitList(*alerts)
+		case "tags":
+			req.Tags = splitList(*tags)
+		case "assign":
+			req.Assignees = splitList(*assign)
+		case "email":
+			req.Emails = splitList(*email)
+		}
+	})
+	return req, false, nil
+}
+
+func runEditCommand(client schedulepb.SchedulerClient, arg string) {
+	cur, err := findSchedule(client, arg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	edited, err := editSchedule(cur)
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	if edited.Title == "" || edited.Datetime == "" {
+		fmt.Println("Title과 Datetime은 필수입니다.")
+		return
+	}
+
+	var mask []string
+	for field, changed := range map[string]bool{
+		"title":     edited.Title != cur.Title,
+		"datetime":  edited.Datetime != wallTime(cur.Datetime, cur.Tz),
+		"url":       edited.Url != cur.Url,
+		"memo":      edited.Memo != cur.Memo,
+		"rrule":     edited.Rrule != cur.Rrule,
+		"alerts":    strings.Join(edited.Alerts, ",") != strings.Join(cur.Alerts, ","),
+		"tags":      strings.Join(edited.Tags, ",") != strings.Join(cur.Tags, ","),
+		"assignees": strings.Join(edited.Assignees, ",") != strings.Join(cur.Assignees, ","),
+		"emails":    strings.Join(edited.Emails, ",") != strings.Join(cur.Emails, ","),
+		"tz":        edited.Tz != cur.Tz,
+	} {
+		if changed {
+			mask = append(mask, field)
+		}
+	}
+	if len(mask) == 0 {
+		fmt.Println("변경된 내용이 없습니다.")
+		return
+	}
+	if slices.Contains(mask, "datetime") && !confirmDatetime(edited, true) {
+		return
+	}
+
+	req := &schedulepb.UpdateScheduleRequest{Id: cur.Id, Schedule: edited, UpdateMask: mask}
+	res, err := client.UpdateSchedule(context.Background(), req)
+	if err != nil {
+		printError("수정 실패", err)
+	} else {
+		fmt.Println("일정 수정됨:", res.Message)
+	}
+}
+
+// findSchedule looks a schedule up by its 1-based list index or by its ID.
+// Like git short hashes, any unambiguous ID prefix is accepted. IDs may be
+// all digits, so a number long enough to be a prefix is tried as one first;
+// "#3" always means the index.
+func findSchedule(client schedulepb.SchedulerClient, arg string) (*schedulepb.ScheduleRequest, error) {
+	if n, ok := strings.CutPrefix(arg, "#"); ok {
+		return scheduleAt(client, n)
+	}
+	_, numErr := strconv.Atoi(arg)
+	if numErr == nil && len(arg) < minIDPrefix {
+		return scheduleAt(client, arg)
+	}
+
+	sch, err := client.GetSchedule(context.Background(), &schedulepb.ScheduleId{Id: arg})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			if numErr == nil {
+				return scheduleAt(client, arg)
+			}
+			return nil, fmt.Errorf("존재하지 않는 일정입니다: %s", arg)
+		case codes.FailedPrecondition:
+			return nil, fmt.Errorf("여러 일정과 일치하는 ID입니다. 더 길게 입력하세요: %s", arg)
+		case codes.InvalidArgument:
+			return nil, fmt.Errorf("ID는 %d자 이상 입력하세요: %s", minIDPrefix, arg)
+		}
+		return nil, fmt.Errorf("일정 조회 실패: %v", err)
+	}
+	return sch, nil
+}
+
+// scheduleAt returns the schedule at the 1-based list index n.
+func scheduleAt(client schedulepb.SchedulerClient, n string) (*schedulepb.ScheduleRequest, error) {
+	idx, err := strconv.Atoi(n)
+	if err != nil {
+		return nil, fmt.Errorf("인덱스는 숫자로 입력하세요: %s", n)
+	}
+	res, err := client.ListSchedules(context.Background(), &schedulepb.ListSchedulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("일정 목록 불러오기 실패: %v", err)
+	}
+	if idx <= 0 || idx > len(res.Schedules) {
+		return nil, fmt.Errorf("존재하지 않는 인덱스입니다.")
+	}
+	return res.Schedules[idx-1], nil
+}
+
+const (
+	// minIDPrefix is the shortest ID prefix the server resolves.
+	minIDPrefix = 4
+	// shortIDLen is how many characters of the ID list prints.
+	shortIDLen = 8
+)
+
+func shortID(id string) string {
+	if len(id) > shortIDLen {
+		return id[:shortIDLen]
+	}
+	return id
+}
+
+const templateHeader = `# 템플릿에 맞춰 일정 정보를 입력하세요. Title 및 Datetime은 필수입니다.
+# Datetime 예) 2025-07-22 18:00  in 90m  tomorrow 9am  next fri 14:00  내일 오후 3시  다음주 월요일 10시
+# Repeat은 반복 일정일 때만 입력하세요. daily | weekly | monthly | yearly 또는 RRULE 형식
+# 예) FREQ=WEEKLY;BYDAY=MO,WE  FREQ=MONTHLY;BYMONTHDAY=15;COUNT=6  FREQ=DAILY;UNTIL=20251231
+# Alerts는 일정 시간 기준으로 알림을 받을 시점입니다. 쉼표로 구분, 비우면 일정 시간에만 알림
+# 예) -1d, -30m, 0  (하루 전, 30분 전, 정각)
+# Tags는 목록 필터에 쓰는 태그입니다. 쉼표로 구분  예) work, team
+# TZ는 Datetime을 해석할 IANA 시간대입니다. 예) Asia/Seoul, America/New_York
+# Assignees는 팀 알림을 받을 사용자 또는 @그룹입니다. 쉼표로 구분, 비우면 나만 받음  예) alice, @ops
+# Emails는 알림 메일을 받을 주소입니다. 쉼표로 구분, 비우면 서버에 설정된 주소로 보냄
+`
+
+// editSchedule opens sch in $EDITOR using the add template and returns the
+// values the user saved.
+func editSchedule(sch *schedulepb.ScheduleRequest) (*schedulepb.ScheduleRequest, error) {
+	tmpfile, err := os.CreateTemp("", "remindme_*.txt")
+	if err != nil {
+		return nil, err
+	}
 	defer os.Remove(tmpfile.Name())
 
-	template := `# 템플릿에 맞춰 일정 정보를 입력하세요. Title 및 Datetime은 필수입니다.
-	Title:
-	Datetime: 2003-03-01 07:30
-	URL:
-	Memo:
-	`
-	
+	tz := sch.Tz
+	if tz == "" {
+		tz = zone.LocalName()
+	}
+	template := templateHeader + fmt.Sprintf("Title: %s\nDatetime: %s\nTZ: %s\nURL: %s\nMemo: %s\nRepeat: %s\nAlerts: %s\nTags: %s\nAssignees: %s\nEmails: %s\n",
+		sch.Title, wallTime(sch.Datetime, sch.Tz), tz, sch.Url, sch.Memo, sch.Rrule, strings.Join(sch.Alerts, ", "),
+		strings.Join(sch.Tags, ", "), strings.Join(sch.Assignees, ", "),
+		strings.Join(sch.Emails, ", "))
+
 	if _, err := tmpfile.Write([]byte(template)); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	tmpfile.Close()
 
@@ -73,16 +371,23 @@ func runAddCommand(client schedulepb.SchedulerClient) {
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		log.Fatalf("에디터 실행 실패: %v", err)
+		return nil, fmt.Errorf("에디터 실행 실패: %v", err)
 	}
 
 	content, err := ioutil.ReadFile(tmpfile.Name())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	title, datetime, url, memo := "", "", "", ""
-	for _, line := range strings.Split(string(content), "\n") {
+	return parseTemplate(string(content)), nil
+}
+
+// parseTemplate reads the "Field: value" lines of the add template.
+// Comments and unknown lines are ignored.
+func parseTemplate(content string) *schedulepb.ScheduleRequest {
+	title, datetime, tz, url, memo, repeat := "", "", "", "", "", ""
+	var alerts, tags, assignees, emails []string
+	for _, line := range strings.Split(content, "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -90,71 +395,280 @@ func runAddCommand(client schedulepb.SchedulerClient) {
 		if strings.HasPrefix(line, "Title:") {
 			title = strings.TrimSpace(strings.TrimPrefix(line, "Title:"))
 		} else if strings.HasPrefix(line, "Datetime:") {
-			datetime =strings.TrimSpace(strings.TrimPrefix(line, "Datetime:"))
+			datetime = strings.TrimSpace(strings.TrimPrefix(line, "Datetime:"))
+		} else if strings.HasPrefix(line, "TZ:") {
+			tz = strings.TrimSpace(strings.TrimPrefix(line, "TZ:"))
 		} else if strings.HasPrefix(line, "URL:") {
-			url =strings.TrimSpace(strings.TrimPrefix(line, "URL:"))
+			url = strings.TrimSpace(strings.TrimPrefix(line, "URL:"))
 		} else if strings.HasPrefix(line, "Memo:") {
-			memo =strings.TrimSpace(strings.TrimPrefix(line, "Memo:"))
+			memo = strings.TrimSpace(strings.TrimPrefix(line, "Memo:"))
+		} else if strings.HasPrefix(line, "Repeat:") {
+			repeat = strings.TrimSpace(strings.TrimPrefix(line, "Repeat:"))
+		} else if strings.HasPrefix(line, "Alerts:") {
+			alerts = splitList(strings.TrimPrefix(line, "Alerts:"))
+		} else if strings.HasPrefix(line, "Tags:") {
+			tags = splitList(strings.TrimPrefix(line, "Tags:"))
+		} else if strings.HasPrefix(line, "Assignees:") {
+			assignees = splitList(strings.TrimPrefix(line, "Assignees:"))
+		} else if strings.HasPrefix(line, "Emails:") {
+			emails = splitList(strings.TrimPrefix(line, "Emails:"))
 		}
 	}
 
-	if title == "" || datetime == "" {
-		fmt.Println("Title과 Datetime은 필수입니다.")
+	return &schedulepb.ScheduleRequest{
+		Title:     title,
+		Datetime:  datetime,
+		Tz:        tz,
+		Url:       url,
+		Memo:      memo,
+		Rrule:     repeat,
+		Alerts:    alerts,
+		Tags:      tags,
+		Assignees: assignees,
+		Emails:    emails,
+	}
+}
+
+// splitList splits a comma-separated option value, dropping blanks.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func runDeleteCommand(client schedulepb.SchedulerClient, arg string) {
+	sch, err := findSchedule(client, arg)
+	if err != nil {
+		fmt.Println(err)
 		return
 	}
 
-	req := &schedulepb.ScheduleRequest{
-		Title:    title,
-		Datetime: datetime,
-		Url:      url,
-		Memo:     memo,
+	res, err := client.DeleteScheduleById(context.Background(), &schedulepb.ScheduleId{Id: sch.Id})
+	if err != nil {
+		fmt.Println("삭제 요청 실패:", err)
+		return
 	}
+	fmt.Println("일정삭제 완료", sch.Title, res.Message)
+}
 
-	res, err := client.AddSchedule(context.Background(), req)
+func runSnoozeCommand(client schedulepb.SchedulerClient, arg, duration string) {
+	sch, err := findSchedule(client, arg)
 	if err != nil {
-		fmt.Println("등록 실패:", err)
-	} else {
-		fmt.Println("일정 추가됨:", res.Message)
+		fmt.Println(err)
+		return
+	}
+
+	res, err := client.SnoozeSchedule(context.Background(), &schedulepb.SnoozeRequest{Id: sch.Id, Duration: duration})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			fmt.Println("아직 알림이 울리지 않은 일정입니다.")
+		case codes.InvalidArgument:
+			fmt.Println("유효한 시간을 입력하세요 (예: 10m, 1h30m).")
+		default:
+			fmt.Println("다시 알림 요청 실패:", err)
+		}
+		return
 	}
+	fmt.Println("다시 알림 설정됨:", sch.Title, res.Message)
 }
 
-func runListCommand(client schedulepb.SchedulerClient) {
-	res, err := client.ListSchedules(context.Background(), &schedulepb.Empty{})
+func runDoneCommand(client schedulepb.SchedulerClient, arg string) {
+	sch, err := findSchedule(client, arg)
 	if err != nil {
-		fmt.Println("일정 목록 불러오기 실패:", err)
+		fmt.Println(err)
 		return
 	}
 
-	if len(res.Schedules) == 0 {
-		fmt.Println("등록된 일정이 없습니다.")
+	res, err := client.AckSchedule(context.Background(), &schedulepb.ScheduleId{Id: sch.Id})
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			fmt.Println("아직 알림이 울리지 않은 일정입니다.")
+		} else {
+			fmt.Println("확인 요청 실패:", err)
+		}
 		return
 	}
+	fmt.Println("알림 확인 완료:", sch.Title, res.Message)
+}
+
+func stateLabel(sch *schedulepb.ScheduleRequest) string {
+	switch sch.State {
+	case "awaiting_ack":
+		return "확인 대기"
+	case "snoozed":
+		if t, err := time.Parse(time.RFC3339, sch.AckDue); err == nil {
+			return "다시 알림 " + t.Local().Format("2006-01-02 15:04")
+		}
+		return "다시 알림"
+	}
+	return ""
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "No\tTitle\tDatetime\tURL\tMemo")
-	for i, sch := range res.Schedules {
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, sch.Title, sch.Datetime, sch.Url, sch.Memo)
+// localTime renders an RFC 3339 instant from the server as a wall-clock
+// time in the local zone. Anything else is shown unchanged.
+func localTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
 	}
-	w.Flush()
+	return t.Local().Format(watcher.Layout)
 }
 
-func runDeleteCommand(client schedulepb.SchedulerClient, idxArg string) {
-	idx, err := strconv.Atoi(idxArg)
-	if err != nil || idx <= 0 {
-		fmt.Println("유효한 숫자 인덱스를 입력하세요")
-		return
+// wallTime renders an RFC 3339 instant as a wall-clock time in the zone
+// the schedule was written in, for editing.
+func wallTime(value, tz string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	loc, err := zone.Load(tz)
+	if err != nil {
+		return value
 	}
+	return t.In(loc).Format(watcher.Layout)
+}
+
+var weekdayLabels = []string{"일", "월", "화", "수", "목", "금", "토"}
 
-	req := &schedulepb.ScheduleIdx{Idx: int32(idx)}
-	res, err := client.DeleteSchedule(context.Background(), req)
+// confirmDatetime resolves the Datetime the user typed, which may be a
+// phrase like "내일 오후 3시", in the schedule's TZ and asks the user to
+// confirm the absolute time. Without ask the time is only shown, for
+// scripted use. On success Datetime holds an RFC 3339 instant.
+func confirmDatetime(sch *schedulepb.ScheduleRequest, ask bool) bool {
+	loc, err := zone.Load(sch.Tz)
 	if err != nil {
-		fmt.Println("삭제 요청 실패:", err)
+		fmt.Println("알 수 없는 시간대입니다:", sch.Tz)
+		return false
+	}
+	t, err := when.Parse(sch.Datetime, time.Now().In(loc))
+	var gap *zone.NonexistentError
+	if errors.As(err, &gap) {
+		fmt.Printf("%s은(는) %s에서 서머타임 전환으로 건너뛰는 시각입니다. 다른 시간을 입력하세요.\n", gap.Wall, gap.Zone)
+		return false
+	}
+	if err != nil {
+		fmt.Println("날짜를 이해하지 못했습니다:", err)
+		return false
+	}
+
+	fmt.Printf("일정 시간: %s (%s) %s\n", t.Format(watcher.Layout), weekdayLabels[t.Weekday()], t.Format("MST"))
+	if first, ambiguous, _ := zone.Resolve(watcher.Layout, t.Format(watcher.Layout), loc); ambiguous {
+		which := "첫 번째"
+		if first.Format("-07:00") != t.Format("-07:00") {
+			which = "두 번째"
+		}
+		fmt.Printf("서머타임 전환으로 이 시각이 두 번 있습니다. %s(%s)로 저장합니다.\n", which, t.Format("-07:00"))
+	}
+	if local := t.Local(); local.Format(time.RFC3339) != t.Format(time.RFC3339) {
+		fmt.Printf("현지 시간: %s (%s) %s\n", local.Format(watcher.Layout), weekdayLabels[local.Weekday()], local.Format("MST"))
+	}
+	if ask {
+		fmt.Print("이대로 저장할까요? [Y/n] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "", "y", "yes", "ㅇ", "예", "네":
+		default:
+			fmt.Println("취소되었습니다.")
+			return false
+		}
+	}
+
+	sch.Datetime = t.Format(time.RFC3339)
+	return true
+}
+
+// fieldLabels maps request fields to the names used in the template.
+var fieldLabels = map[string]string{
+	"title":     "Title",
+	"datetime":  "Datetime",
+	"tz":        "TZ",
+	"url":       "URL",
+	"memo":      "Memo",
+	"rrule":     "Repeat",
+	"alerts":    "Alerts",
+	"tags":      "Tags",
+	"assignees": "Assignees",
+	"emails":    "Emails",
+}
+
+// printError prints an RPC failure. Validation errors are listed per
+// template field.
+func printError(prefix string, err error) {
+	st := status.Convert(err)
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			violations = append(violations, br.FieldViolations...)
+		}
+	}
+	if len(violations) == 0 {
+		fmt.Printf("%s: %s\n", prefix, st.Message())
 		return
 	}
 
-	if res.Message == "Invalid index" {
-		fmt.Println("존재하지 않는 인덱스입니다.")
+	fmt.Printf("%s: 입력값을 확인하세요.\n", prefix)
+	for _, fv := range violations {
+		label := fieldLabels[fv.Field]
+		if label == "" {
+			label = fv.Field
+		}
+		fmt.Printf("  %s: %s\n", label, fv.Description)
+	}
+}
+
+// scheduleJSON is the JSON form accepted by add --from. Its keys match
+// the template fields.
+type scheduleJSON struct {
+	Title     string   `json:"title"`
+	Datetime  string   `json:"datetime"`
+	TZ        string   `json:"tz"`
+	URL       string   `json:"url"`
+	Memo      string   `json:"memo"`
+	Repeat    string   `json:"repeat"`
+	Alerts    []string `json:"alerts"`
+	Tags      []string `json:"tags"`
+	Assignees []string `json:"assignees"`
+	Emails    []string `json:"emails"`
+}
+
+// readSchedule reads a schedule from path, or from stdin when path is
+// "-". The input is either the add template or a JSON object.
+func readSchedule(path string) (*schedulepb.ScheduleRequest, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
 	} else {
-		fmt.Println("일정삭제 완료", res.Message)
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(string(content)), "{") {
+		return parseTemplate(string(content)), nil
 	}
-}
\ No newline at end of file
+	var in scheduleJSON
+	dec := json.NewDecoder(strings.NewReader(string(content)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		return nil, fmt.Errorf("JSON 형식 오류: %v", err)
+	}
+	return &schedulepb.ScheduleRequest{
+		Title:     in.Title,
+		Datetime:  in.Datetime,
+		Tz:        in.TZ,
+		Url:       in.URL,
+		Memo:      in.Memo,
+		Rrule:     in.Repeat,
+		Alerts:    in.Alerts,
+		Tags:      in.Tags,
+		Assignees: in.Assignees,
+		Emails:    in.Emails,
+	}, nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// tempWriter returns a function that writes a file in a temporary
+// directory and returns its path.
+func tempWriter(t *testing.T) func(name, content string) string {
+	dir := t.TempDir()
+	return func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+}
+
+func TestAddRequest(t *testing.T) {
+	write := tempWriter(t)
+	template := write("template.txt", "# 새 일정\nTitle: 회의\nDatetime: 2025-07-22 18:00\nMemo: 3층\nTags: work, team\n")
+	jsonFile := write("schedule.json", `{"title": "회의", "datetime": "tomorrow 9am", "tz": "Asia/Seoul", "alerts": ["-10m"], "emails": ["a@example.com"]}`)
+
+	for _, c := range []struct {
+		name string
+		args []string
+		want *schedulepb.ScheduleRequest
+	}{
+		{"flags", []string{"--title", "회의", "--at", "내일 오후 3시", "--tz", "Asia/Seoul", "--url", "https://example.com", "--memo", "3층",
+			"--repeat", "weekly", "--alerts", "-1d, -30m,", "--tags", "work,team", "--assign", "alice,@ops", "--email", "a@example.com"},
+			&schedulepb.ScheduleRequest{Title: "회의", Datetime: "내일 오후 3시", Tz: "Asia/Seoul", Url: "https://example.com", Memo: "3층",
+				Rrule: "weekly", Alerts: []string{"-1d", "-30m"}, Tags: []string{"work", "team"}, Assignees: []string{"alice", "@ops"},
+				Emails: []string{"a@example.com"}}},
+		{"template", []string{"--from", template},
+			&schedulepb.ScheduleRequest{Title: "회의", Datetime: "2025-07-22 18:00", Memo: "3층", Tags: []string{"work", "team"}}},
+		{"json", []string{"--from", jsonFile},
+			&schedulepb.ScheduleRequest{Title: "회의", Datetime: "tomorrow 9am", Tz: "Asia/Seoul", Alerts: []string{"-10m"}, Emails: []string{"a@example.com"}}},
+		{"flags override from", []string{"--from", template, "--title", "점심", "--memo", "", "--tags", ""},
+			&schedulepb.ScheduleRequest{Title: "점심", Datetime: "2025-07-22 18:00"}},
+		{"flags alone", []string{"--at", "in 90m"},
+			&schedulepb.ScheduleRequest{Datetime: "in 90m"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, interactive, err := addRequest(c.args, flag.ContinueOnError)
+			if err != nil || interactive {
+				t.Fatalf("addRequest(%q) = interactive %v, err %v", c.args, interactive, err)
+			}
+			if !proto.Equal(got, c.want) {
+				t.Errorf("addRequest(%q)\n got %v\nwant %v", c.args, got, c.want)
+			}
+		})
+	}
+}
+
+func TestAddRequest_Stdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	w.WriteString(`{"title": "표준 입력", "datetime": "2025-07-22 18:00"}`)
+	w.Close()
+
+	got, _, err := addRequest([]string{"--from", "-"}, flag.ContinueOnError)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "표준 입력" || got.Datetime != "2025-07-22 18:00" {
+		t.Errorf("addRequest(--from -) = %v", got)
+	}
+}
+
+func TestAddRequest_Interactive(t *testing.T) {
+	if _, interactive, err := addRequest(nil, flag.ContinueOnError); !interactive || err != nil {
+		t.Errorf("addRequest() = interactive %v, err %v; want the editor", interactive, err)
+	}
+}
+
+func TestAddRequest_Errors(t *testing.T) {
+	write := tempWriter(t)
+
+	for _, c := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown flag", []string{"--titel", "회의"}, "flag provided but not defined"},
+		{"missing value", []string{"--title"}, "flag needs an argument"},
+		{"missing file", []string{"--from", write("nope.json", "") + ".missing"}, "no such file"},
+		{"bad json", []string{"--from", write("bad.json", `{"title": "회의",}`)}, "JSON 형식 오류"},
+		{"unknown key", []string{"--from", write("key.json", `{"title": "회의", "when": "today"}`)}, "unknown field \"when\""},
+		{"wrong type", []string{"--from", write("type.json", `{"title": "회의", "alerts": "-10m"}`)}, "JSON 형식 오류"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			_, _, err := addRequest(c.args, flag.ContinueOnError)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("addRequest(%q) error = %v, want one containing %q", c.args, err, c.want)
+			}
+		})
+	}
+}
+
+// idClient resolves IDs and prefixes like the server does.
+type idClient struct {
+	schedulepb.SchedulerClient
+	list []*schedulepb.ScheduleRequest
+}
+
+func (c idClient) ListSchedules(context.Context, *schedulepb.ListSchedulesRequest, ...grpc.CallOption) (*schedulepb.ScheduleList, error) {
+	return &schedulepb.ScheduleList{Schedules: c.list}, nil
+}
+
+func (c idClient) GetSchedule(_ context.Context, req *schedulepb.ScheduleId, _ ...grpc.CallOption) (*schedulepb.ScheduleRequest, error) {
+	if len(req.Id) < minIDPrefix {
+		return nil, status.Error(codes.InvalidArgument, "too short")
+	}
+	var matches []*schedulepb.ScheduleRequest
+	for _, sch := range c.list {
+		if strings.HasPrefix(sch.Id, req.Id) {
+			matches = append(matches, sch)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, status.Error(codes.NotFound, "not found")
+	case 1:
+		return matches[0], nil
+	}
+	return nil, status.Error(codes.FailedPrecondition, "ambiguous")
+}
+
+func TestFindSchedule(t *testing.T) {
+	client := idClient{}
+	for i := range 12 {
+		client.list = append(client.list, &schedulepb.ScheduleRequest{Id: fmt.Sprintf("%04x-%d", 0xa000+i, i), Title: fmt.Sprint(i + 1)})
+	}
+	// IDs that happen to be all digits.
+	client.list = append(client.list,
+		&schedulepb.ScheduleRequest{Id: "12345678-0001", Title: "digits"},
+		&schedulepb.ScheduleRequest{Id: "55550000-0001", Title: "twin 1"},
+		&schedulepb.ScheduleRequest{Id: "55551111-0002", Title: "twin 2"},
+	)
+
+	for _, c := range []struct {
+		arg, want string
+	}{
+		{"3", "3"},
+		{"#3", "3"},
+		{"12", "12"},
+		{"a00b", "12"},
+		{"a00b-11", "12"},
+		// A number that is an ID prefix means the ID, and #n the index.
+		{"1234", "digits"},
+		{"12345678", "digits"},
+		{"#13", "digits"},
+		// A long number that is no ID prefix is still an index.
+		{"0013", "digits"},
+		{"0014", "twin 1"},
+	} {
+		sch, err := findSchedule(client, c.arg)
+		if err != nil {
+			t.Errorf("findSchedule(%q): %v", c.arg, err)
+			continue
+		}
+		if sch.Title != c.want {
+			t.Errorf("findSchedule(%q) = %s, want %s", c.arg, sch.Title, c.want)
+		}
+	}
+
+	for _, c := range []struct {
+		arg, want string
+	}{
+		{"0", "존재하지 않는 인덱스"},
+		{"16", "존재하지 않는 인덱스"},
+		{"#99", "존재하지 않는 인덱스"},
+		{"#a00b", "인덱스는 숫자로"},
+		{"9999", "존재하지 않는 인덱스"},
+		{"5555", "여러 일정과 일치"},
+		{"ffff", "존재하지 않는 일정"},
+		{"ab", "4자 이상"},
+	} {
+		if _, err := findSchedule(client, c.arg); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("findSchedule(%q) error = %v, want one containing %q", c.arg, err, c.want)
+		}
+	}
+}
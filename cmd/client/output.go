@@ -0,0 +1,256 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"gopkg.in/yaml.v3"
+)
+
+var outputFormats = []string{"table", "json", "yaml", "csv", "tsv"}
+
+// scheduleRow is the script-facing view of a schedule. Its field names are
+// stable across releases; times are RFC 3339.
+type scheduleRow struct {
+	Index     int      `json:"index,omitempty" yaml:"index,omitempty"`
+	ID        string   `json:"id" yaml:"id"`
+	Title     string   `json:"title" yaml:"title"`
+	Datetime  string   `json:"datetime" yaml:"datetime"`
+	TZ        string   `json:"tz" yaml:"tz"`
+	NextFire  string   `json:"next_fire" yaml:"next_fire"`
+	Alerts    []string `json:"alerts" yaml:"alerts"`
+	Repeat    string   `json:"repeat" yaml:"repeat"`
+	Tags      []string `json:"tags" yaml:"tags"`
+	State     string   `json:"state" yaml:"state"`
+	URL       string   `json:"url" yaml:"url"`
+	Memo      string   `json:"memo" yaml:"memo"`
+	Owner     string   `json:"owner" yaml:"owner"`
+	Assignees []string `json:"assignees" yaml:"assignees"`
+	AckedBy   []string `json:"acked_by" yaml:"acked_by"`
+	Pending   []string `json:"pending" yaml:"pending"`
+}
+
+func newScheduleRow(index int, sch *schedulepb.ScheduleRequest) scheduleRow {
+	alerts, tags := sch.Alerts, sch.Tags
+	if alerts == nil {
+		alerts = []string{}
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return scheduleRow{
+		Index:     index,
+		ID:        sch.Id,
+		Title:     sch.Title,
+		Datetime:  sch.Datetime,
+		TZ:        sch.Tz,
+		NextFire:  nextFire(sch),
+		Alerts:    alerts,
+		Repeat:    sch.Rrule,
+		Tags:      tags,
+		State:     sch.State,
+		URL:       sch.Url,
+		Memo:      sch.Memo,
+		Owner:     sch.Owner,
+		Assignees: nonNil(sch.Assignees),
+		AckedBy:   nonNil(sch.AckedBy),
+		Pending:   nonNil(pending(sch)),
+	}
+}
+
+// pending lists the members who have not acknowledged the current
+// occurrence of a team schedule that is waiting for acks.
+func pending(sch *schedulepb.ScheduleRequest) []string {
+	if sch.State == "" {
+		return nil
+	}
+	var out []string
+	for _, m := range sch.Members {
+		if !slices.Contains(sch.AckedBy, m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// nextFire is when the schedule will next notify: the re-notify time while
+// it waits for an ack, otherwise the server's next alert.
+func nextFire(sch *schedulepb.ScheduleRequest) string {
+	if sch.State != "" && sch.AckDue != "" {
+		return sch.AckDue
+	}
+	return sch.NextAlert
+}
+
+func (r scheduleRow) header() []string {
+	return []string{"index", "id", "title", "datetime", "tz", "next_fire", "alerts", "repeat", "tags", "state", "url", "memo", "owner",
+		"assignees", "acked_by", "pending"}
+}
+
+func (r scheduleRow) fields() []string {
+	index := ""
+	if r.Index > 0 {
+		index = strconv.Itoa(r.Index)
+	}
+	return []string{index, r.ID, r.Title, r.Datetime, r.TZ, r.NextFire,
+		strings.Join(r.Alerts, ","), r.Repeat, strings.Join(r.Tags, ","), r.State, r.URL, r.Memo, r.Owner,
+		strings.Join(r.Assignees, ","), strings.Join(r.AckedBy, ","), strings.Join(r.Pending, ",")}
+}
+
+// eventRow is a watch event with its schedule flattened into it.
+type eventRow struct {
+	Event       string `json:"event" yaml:"event"`
+	Time        string `json:"time" yaml:"time"`
+	Alert       string `json:"alert" yaml:"alert"`
+	User        string `json:"user,omitempty" yaml:"user,omitempty"`
+	scheduleRow `yaml:",inline"`
+}
+
+func (r eventRow) header() []string {
+	return append([]string{"event", "time", "alert", "user"}, r.scheduleRow.header()[1:]...)
+}
+
+func (r eventRow) fields() []string {
+	return append([]string{r.Event, r.Time, r.Alert, r.User}, r.scheduleRow.fields()[1:]...)
+}
+
+// record is a row that can be written as CSV or TSV.
+type record interface {
+	header() []string
+	fields() []string
+}
+
+// outputFlags registers --output and --template on a command's flags.
+type outputFlags struct {
+	format *string
+	tmpl   *string
+}
+
+func addOutputFlags(fs *flag.FlagSet) outputFlags {
+	o := outputFlags{
+		format: fs.String("output", "table", "출력 형식: "+strings.Join(outputFormats, "|")),
+		tmpl:   fs.String("template", "", "일정마다 실행할 Go text/template (예: '{{.ID}} {{.Title}}')"),
+	}
+	fs.StringVar(o.format, "o", "table", "--output 의 줄임")
+	return o
+}
+
+// printer writes records in the format chosen by outputFlags. Table output
+// is left to the caller.
+type printer struct {
+	w       io.Writer
+	format  string
+	tmpl    *template.Template
+	started bool
+}
+
+func (o outputFlags) printer(w io.Writer) (*printer, error) {
+	p := &printer{w: w, format: *o.format}
+	if *o.tmpl != "" {
+		t, err := template.New("output").Parse(*o.tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("템플릿 오류: %v", err)
+		}
+		p.tmpl, p.format = t, "template"
+		return p, nil
+	}
+	for _, f := range outputFormats {
+		if f == p.format {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("알 수 없는 출력 형식 %q (%s)", p.format, strings.Join(outputFormats, "|"))
+}
+
+func (p *printer) table() bool {
+	return p.format == "table"
+}
+
+// list writes a complete result: a JSON array, a YAML sequence, or rows
+// under a single header.
+func (p *printer) list(recs []record) error {
+	switch p.format {
+	case "json":
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(recs)
+	case "yaml":
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(recs); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	for _, r := range recs {
+		if err := p.stream(r); err != nil {
+			return err
+		}
+	}
+	if !p.started && (p.format == "csv" || p.format == "tsv") {
+		return p.writeRow((scheduleRow{}).header())
+	}
+	return nil
+}
+
+// stream writes one record of an open-ended result: JSON lines, YAML
+// documents, or CSV/TSV rows after a single header.
+func (p *printer) stream(r record) error {
+	first := !p.started
+	p.started = true
+	switch p.format {
+	case "template":
+		if err := p.tmpl.Execute(p.w, r); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(p.w)
+		return err
+	case "json":
+		return json.NewEncoder(p.w).Encode(r)
+	case "yaml":
+		if !first {
+			fmt.Fprintln(p.w, "---")
+		}
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		return enc.Encode(r)
+	}
+	if first {
+		if err := p.writeRow(r.header()); err != nil {
+			return err
+		}
+	}
+	return p.writeRow(r.fields())
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func (p *printer) writeRow(fields []string) error {
+	if p.format == "csv" {
+		w := csv.NewWriter(p.w)
+		w.Write(fields)
+		w.Flush()
+		return w.Error()
+	}
+	escaped := make([]string, len(fields))
+	for i, f := range fields {
+		escaped[i] = tsvEscaper.Replace(f)
+	}
+	_, err := fmt.Fprintln(p.w, strings.Join(escaped, "\t"))
+	return err
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// golden compares got with testdata/name, or rewrites the file with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file\n got:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+// newPrinter returns a printer as the --output and --template flags would.
+func newPrinter(t *testing.T, w *bytes.Buffer, args ...string) (*printer, error) {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	o := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return o.printer(w)
+}
+
+var testSchedules = []*schedulepb.ScheduleRequest{
+	{Id: "3f2a9c1e-0000-4000-8000-000000000001", Title: "회의, \"분기\" 점검", Datetime: "2025-07-22T18:00:00+09:00", Tz: "Asia/Seoul",
+		NextAlert: "2025-07-22T17:30:00+09:00", Alerts: []string{"-30m", "0"}, Rrule: "FREQ=WEEKLY", Tags: []string{"work", "team"},
+		Url: "https://example.com/?a=1&b=2", Memo: "3층\t회의실\n자료는 C:\\share 참고\r"},
+	{Id: "3f2a9c1e-0000-4000-8000-000000000002", Title: "배포", Datetime: "2025-07-23T09:00:00Z", Tz: "UTC", State: "fired",
+		AckDue: "2025-07-23T09:05:00Z", NextAlert: "2025-07-30T09:00:00Z", Owner: "alice", Assignees: []string{"alice", "@ops"},
+		Members: []string{"alice", "bob", "carol"}, AckedBy: []string{"bob"}},
+}
+
+var testEvents = []eventRow{
+	{Event: "fired", Time: "2025-07-22T17:30:00+09:00", Alert: "-30m", scheduleRow: newScheduleRow(0, testSchedules[0])},
+	{Event: "acknowledged", Time: "2025-07-23T09:01:00Z", User: "bob", scheduleRow: newScheduleRow(0, testSchedules[1])},
+}
+
+func TestPrinter_List(t *testing.T) {
+	var recs []record
+	for i, sch := range testSchedules {
+		recs = append(recs, newScheduleRow(i+1, sch))
+	}
+	for _, format := range []string{"json", "yaml", "csv", "tsv", "template"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			args := []string{"-o", format}
+			if format == "template" {
+				args = []string{"--template", "{{.Index}}. {{.Title}} [{{range $i, $t := .Tags}}{{if $i}},{{end}}{{$t}}{{end}}] {{.Pending}}"}
+			}
+			p, err := newPrinter(t, &out, args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.list(recs); err != nil {
+				t.Fatal(err)
+			}
+			golden(t, "list."+format, out.Bytes())
+		})
+	}
+}
+
+func TestPrinter_ListEmpty(t *testing.T) {
+	for format, want := range map[string]string{
+		"json": "[]\n",
+		"yaml": "[]\n",
+		"csv":  strings.Join((scheduleRow{}).header(), ",") + "\n",
+		"tsv":  strings.Join((scheduleRow{}).header(), "\t") + "\n",
+	} {
+		var out bytes.Buffer
+		p, err := newPrinter(t, &out, "-o", format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.list([]record{}); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != want {
+			t.Errorf("%s: empty list = %q, want %q", format, out.String(), want)
+		}
+	}
+}
+
+func TestPrinter_Stream(t *testing.T) {
+	for _, format := range []string{"json", "yaml", "csv", "tsv", "template"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			args := []string{"-o", format}
+			if format == "template" {
+				args = []string{"--template", "{{.Event}} {{.Title}}{{with .User}} ({{.}}){{end}}"}
+			}
+			p, err := newPrinter(t, &out, args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ev := range testEvents {
+				if err := p.stream(ev); err != nil {
+					t.Fatal(err)
+				}
+			}
+			golden(t, "watch."+format, out.Bytes())
+		})
+	}
+}
+
+func TestPrinter_TSVEscaping(t *testing.T) {
+	var out bytes.Buffer
+	p, _ := newPrinter(t, &out, "-o", "tsv")
+	p.writeRow([]string{"a\tb", "line1\nline2\r", `C:\share`, ""})
+	if got, want := out.String(), `a\tb`+"\t"+`line1\nline2\r`+"\t"+`C:\\share`+"\t\n"; got != want {
+		t.Errorf("TSV row = %q, want %q", got, want)
+	}
+	// Every row keeps one line and the same number of columns.
+	out.Reset()
+	p, _ = newPrinter(t, &out, "-o", "tsv")
+	p.list([]record{newScheduleRow(1, testSchedules[0])})
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("TSV output has %d lines, want a header and one row:\n%s", len(lines), out.String())
+	}
+	if h, r := strings.Count(lines[0], "\t"), strings.Count(lines[1], "\t"); h != r {
+		t.Errorf("TSV header has %d tabs, row %d", h, r)
+	}
+}
+
+func TestPrinter_Errors(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := newPrinter(t, &out, "-o", "xml"); err == nil || !strings.Contains(err.Error(), "알 수 없는 출력 형식") {
+		t.Errorf("unknown format: got %v", err)
+	}
+	if _, err := newPrinter(t, &out, "--template", "{{.Title"); err == nil || !strings.Contains(err.Error(), "템플릿 오류") {
+		t.Errorf("unparsable template: got %v", err)
+	}
+	if _, err := newPrinter(t, &out, "--template", "{{join .Tags}}"); err == nil || !strings.Contains(err.Error(), `function "join" not defined`) {
+		t.Errorf("unknown function: got %v", err)
+	}
+
+	p, err := newPrinter(t, &out, "--template", "{{.Nope}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = p.list([]record{newScheduleRow(1, testSchedules[0])})
+	if err == nil || !strings.Contains(err.Error(), "can't evaluate field Nope") {
+		t.Errorf("unknown field: got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+)
+
+// eventNames maps the names accepted by --type to event types.
+var eventNames = map[string]schedulepb.EventType{
+	"added":   schedulepb.EventType_EVENT_TYPE_ADDED,
+	"updated": schedulepb.EventType_EVENT_TYPE_UPDATED,
+	"deleted": schedulepb.EventType_EVENT_TYPE_DELETED,
+	"fired":   schedulepb.EventType_EVENT_TYPE_FIRED,
+	"missed":  schedulepb.EventType_EVENT_TYPE_MISSED,
+	"snoozed": schedulepb.EventType_EVENT_TYPE_SNOOZED,
+	"acked":   schedulepb.EventType_EVENT_TYPE_ACKNOWLEDGED,
+}
+
+func eventName(t schedulepb.EventType) string {
+	for name, et := range eventNames {
+		if et == t {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+func runWatchCommand(client schedulepb.SchedulerClient, args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	types := fs.String("type", "", "받을 이벤트 종류 (쉼표로 구분: added,updated,deleted,fired,missed,snoozed,acked)")
+	hook := fs.String("exec", "", "이벤트마다 실행할 셸 명령. 일정 정보는 REMINDME_* 환경 변수로 전달")
+	out := addOutputFlags(fs)
+	fs.Parse(args)
+	p, err := out.printer(os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	req := &schedulepb.WatchRequest{}
+	if *types != "" {
+		for _, name := range strings.Split(*types, ",") {
+			t, ok := eventNames[strings.TrimSpace(name)]
+			if !ok {
+				fmt.Println("알 수 없는 이벤트 종류:", name)
+				return
+			}
+			req.Types = append(req.Types, t)
+		}
+	}
+
+	// The server may restart; keep reconnecting until interrupted.
+	for {
+		err := watchEvents(client, req, *hook, p)
+		fmt.Fprintln(os.Stderr, "이벤트 스트림 끊김:", err, "- 5초 후 다시 연결합니다.")
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func watchEvents(client schedulepb.SchedulerClient, req *schedulepb.WatchRequest, hook string, p *printer) error {
+	stream, err := client.WatchEvents(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("server closed the stream")
+		}
+		if err != nil {
+			return err
+		}
+
+		sch := ev.Schedule
+		if p.table() {
+			name := eventName(ev.Type)
+			if ev.Alert != "" {
+				name += " " + ev.Alert
+			}
+			if ev.User != "" {
+				name += " (" + ev.User + ")"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", ev.Time, name, shortID(sch.Id), localTime(sch.Datetime), sch.Title)
+		} else if err := p.stream(eventRow{
+			Event:       eventName(ev.Type),
+			Time:        ev.Time,
+			Alert:       ev.Alert,
+			User:        ev.User,
+			scheduleRow: newScheduleRow(0, sch),
+		}); err != nil {
+			return err
+		}
+		if hook != "" {
+			runHook(hook, ev)
+		}
+	}
+}
+
+// runHook runs the --exec command for one event, passing the event through
+// the environment.
+func runHook(hook string, ev *schedulepb.ScheduleEvent) {
+	sch := ev.Schedule
+	cmd := exec.Command("sh", "-c", hook)
+	cmd.Env = append(os.Environ(),
+		"REMINDME_EVENT="+eventName(ev.Type),
+		"REMINDME_TIME="+ev.Time,
+		"REMINDME_ID="+sch.Id,
+		"REMINDME_TITLE="+sch.Title,
+		"REMINDME_DATETIME="+sch.Datetime,
+		"REMINDME_TZ="+sch.Tz,
+		"REMINDME_URL="+sch.Url,
+		"REMINDME_MEMO="+sch.Memo,
+		"REMINDME_RRULE="+sch.Rrule,
+		"REMINDME_ALERT="+ev.Alert,
+		"REMINDME_USER="+ev.User,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Println("훅 실행 실패:", err)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/certs"
+	"github.com/je0ng3/remindme-cli/internal/config"
+)
+
+const certsUsage = "usage: remindserver certs init [-dir dir] [-host name,...] [-days n] [-force] [client ...]"
+
+// runCertsCommand handles "remindserver certs init", which issues a CA, a
+// server certificate and one client certificate per name.
+func runCertsCommand(args []string) {
+	if len(args) == 0 || args[0] != "init" {
+		fmt.Fprintln(os.Stderr, certsUsage)
+		os.Exit(2)
+	}
+	fs := flag.NewFlagSet("certs init", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), certsUsage)
+		fs.PrintDefaults()
+	}
+	dir := fs.String("dir", defaultCertsDir(), "directory for the CA and certificates")
+	hosts := fs.String("host", defaultHosts(), "comma-separated DNS names and IPs the server certificate is valid for")
+	days := fs.Int("days", 825, "validity of new certificates in days")
+	force := fs.Bool("force", false, "reissue server and client certificates that already exist")
+	fs.Parse(args[1:])
+
+	var hostList []string
+	for _, h := range strings.Split(*hosts, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hostList = append(hostList, h)
+		}
+	}
+	if len(hostList) == 0 {
+		fmt.Fprintln(os.Stderr, "-host needs at least one DNS name or IP")
+		os.Exit(2)
+	}
+	written, err := certs.Init(certs.Request{
+		Dir:     *dir,
+		Hosts:   hostList,
+		Clients: fs.Args(),
+		Valid:   time.Duration(*days) * 24 * time.Hour,
+		Force:   *force,
+	})
+	for _, f := range written {
+		fmt.Println("wrote", f)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(written) == 0 {
+		fmt.Println("nothing to do: all certificates exist (use -force to reissue)")
+		return
+	}
+
+	fmt.Printf(`
+server config:
+  listen: ":50051"
+  tls_cert: %s
+  tls_key: %s
+  client_ca: %s
+
+give each member %s and their own certificate and key:
+  client:
+    server: %s:50051
+    ca: ca.pem
+    cert: <name>.pem
+    key: <name>-key.pem
+`, filepath.Join(*dir, certs.CertFile("server")), filepath.Join(*dir, certs.KeyFile("server")),
+		filepath.Join(*dir, certs.CAFile), filepath.Join(*dir, certs.CAFile), hostList[0])
+}
+
+func defaultCertsDir() string {
+	p := config.DefaultPath()
+	if p == "" {
+		return "certs"
+	}
+	return filepath.Join(filepath.Dir(p), "certs")
+}
+
+func defaultHosts() string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if name, err := os.Hostname(); err == nil && name != "localhost" {
+		hosts = append([]string{name}, hosts...)
+	}
+	return strings.Join(hosts, ",")
+}
@@ -1,27 +1,259 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/certs"
+	"github.com/je0ng3/remindme-cli/internal/config"
+	"github.com/je0ng3/remindme-cli/internal/notify"
 	"github.com/je0ng3/remindme-cli/internal/server"
+	"github.com/je0ng3/remindme-cli/internal/store"
+	"github.com/je0ng3/remindme-cli/internal/unixsock"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"github.com/je0ng3/remindme-cli/internal/zone"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
-	lis, err := net.Listen("tcp", ":50051")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "certs":
+			runCertsCommand(os.Args[2:])
+			return
+		case "user":
+			runUserCommand(os.Args[2:])
+			return
+		}
+	}
+
+	configPath := config.PathFromArgs(os.Args[1:])
+	cfg, sources, err := config.LoadServer(configPath)
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Notifier == "" {
+		cfg.Notifier = notify.Default()
+	}
+	if cfg.NotifierOpts == nil {
+		cfg.NotifierOpts = map[string]string{}
+	}
+
+	allowFlag := false
+	flag.String("config", configPath, "config file (env REMINDME_CONFIG)")
+	flag.StringVar(&cfg.Listen, "listen", cfg.Listen, "TCP address or unix:socket-path to listen on (env REMINDME_LISTEN)")
+	flag.Func("allow-uid", "user ID besides the server's own allowed on the socket (repeatable, env REMINDME_ALLOW_UIDS)", func(v string) error {
+		uid, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid uid %q", v)
+		}
+		if !allowFlag {
+			// The first flag replaces the file and environment lists.
+			cfg.AllowUIDs, allowFlag = nil, true
+		}
+		cfg.AllowUIDs = append(cfg.AllowUIDs, uid)
+		return nil
+	})
+	flag.StringVar(&cfg.Store, "store", cfg.Store, "storage backend (csv|sqlite)")
+	flag.StringVar(&cfg.Data, "data", cfg.Data, "path of the schedule file (default data/schedules.csv or data/schedules.db)")
+	flag.StringVar(&cfg.Missed, "missed", cfg.Missed, "policy for reminders that passed while the server was down (fire|skip)")
+	flag.DurationVar(&cfg.RenotifyInterval, "renotify-interval", cfg.RenotifyInterval, "how often an unacknowledged reminder is notified again")
+	flag.IntVar(&cfg.RenotifyLimit, "renotify-limit", cfg.RenotifyLimit, "how many times an unacknowledged reminder is notified again (0 disables acknowledgement)")
+	flag.StringVar(&cfg.Notifier, "notifier", cfg.Notifier, "notification backends, comma-separated ("+strings.Join(notify.Names(), "|")+")")
+	flag.Func("notifier-opt", "backend option as key=value (repeatable)", func(v string) error {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return fmt.Errorf("expected key=value, got %q", v)
+		}
+		cfg.NotifierOpts[key] = value
+		return nil
+	})
+	flag.StringVar(&cfg.LogLevel, "log-level", cfg.LogLevel, "minimum log level (debug|info|warn|error)")
+	flag.StringVar(&cfg.TZ, "tz", cfg.TZ, "time zone for schedules added without one (default the system zone)")
+	flag.StringVar(&cfg.TLSCert, "tls-cert", cfg.TLSCert, "TLS certificate for a TCP listener (env REMINDME_TLS_CERT)")
+	flag.StringVar(&cfg.TLSKey, "tls-key", cfg.TLSKey, "key of the TLS certificate (env REMINDME_TLS_KEY)")
+	flag.StringVar(&cfg.ClientCA, "client-ca", cfg.ClientCA, "CA bundle that client certificates must be signed by (enables mutual TLS)")
+	flag.Func("group", "team as name=user,user (repeatable)", func(v string) error {
+		name, members, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("expected name=user,user, got %q", v)
+		}
+		if cfg.Groups == nil {
+			cfg.Groups = map[string][]string{}
+		}
+		cfg.Groups[name] = strings.Split(members, ",")
+		return nil
+	})
+	flag.StringVar(&cfg.Auth, "auth", cfg.Auth, "authentication of calls (off|token)")
+	flag.StringVar(&cfg.Users, "users", cfg.Users, "token file of remindserver user (default users.csv next to -data)")
+	flag.StringVar(&cfg.Admin, "admin", cfg.Admin, "user who owns the schedules added while authentication was off")
+	flag.Parse()
+	sources.MarkFlags(flag.CommandLine)
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "notifier-opt":
+			sources["notifier_opts"] = "flag"
+		case "allow-uid":
+			sources["allow_uids"] = "flag"
+		case "group":
+			sources["groups"] = "flag"
+		}
+	})
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		log.Fatalf("invalid log level %q", cfg.LogLevel)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	if cfg.TZ != "" {
+		loc, err := zone.Load(cfg.TZ)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Everything that reads wall-clock times falls back to the local
+		// zone, so the default zone is simply the process's local zone.
+		time.Local = loc
+		os.Setenv("TZ", cfg.TZ)
+	}
+
+	notifier, err := notify.New(cfg.Notifier, cfg.NotifierOpts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	policy, err := watcher.ParseMissedPolicy(cfg.Missed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg.Data = cfg.DataPath()
+	st, err := store.Open(cfg.Store, cfg.Data)
+	if err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+	defer st.Close()
+	if c, ok := st.(store.Checker); ok {
+		n, err := c.Check()
+		if err != nil {
+			log.Fatalf("failed to check store: %v", err)
+		}
+		if n > 0 {
+			slog.Warn("quarantined malformed rows", "count", n, "path", cfg.Data+".quarantine")
+		}
+	}
+
+	users := auth.Open(cfg.UsersPath())
+	list, err := users.List()
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		log.Fatalf("failed to read users: %v", err)
+	}
+	var unaries []grpc.UnaryServerInterceptor
+	var streams []grpc.StreamServerInterceptor
+	switch cfg.Auth {
+	case "token":
+		if len(list) == 0 {
+			slog.Warn("no users: every call is refused until remindserver user add", "path", users.Path())
+		}
+		unary, stream := auth.Interceptors(users)
+		unaries = append(unaries, unary)
+		streams = append(streams, stream)
+	case "off":
+		if len(list) > 0 {
+			log.Fatalf("%s has users but authentication is off; set -auth token or remove the file", users.Path())
+		}
+		if cfg.Admin != "" {
+			log.Fatal("-admin needs -auth token")
+		}
+	default:
+		log.Fatalf("invalid auth %q: use off or token", cfg.Auth)
+	}
+
+	var lis net.Listener
+	var opts []grpc.ServerOption
+	switch network, addr := unixsock.Split(cfg.Listen); network {
+	case "unix":
+		if cfg.TLSCert != "" || cfg.ClientCA != "" {
+			log.Fatal("TLS needs a TCP listen address; the socket is already private")
+		}
+		// Other allowed users must be able to open the socket; their
+		// peer credentials are checked below.
+		lis, err = unixsock.Listen(addr, len(cfg.AllowUIDs) > 0)
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		defer lis.Close()
+		// Peer credentials are checked before any token.
+		unary, stream := unixsock.Authorize(append([]int{os.Getuid()}, cfg.AllowUIDs...))
+		unaries = append([]grpc.UnaryServerInterceptor{unary}, unaries...)
+		streams = append([]grpc.StreamServerInterceptor{stream}, streams...)
+		opts = append(opts, grpc.Creds(unixsock.Credentials()))
+	default:
+		lis, err = net.Listen(network, addr)
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		switch {
+		case cfg.TLSCert != "":
+			tlsConfig, err := certs.ServerConfig(cfg.TLSCert, cfg.TLSKey, cfg.ClientCA)
+			if err != nil {
+				log.Fatalf("failed to load TLS certificate: %v", err)
+			}
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+			if cfg.ClientCA == "" {
+				slog.Warn("TLS without client certificates: anyone who can reach the server can use it", "addr", addr)
+			}
+		case cfg.ClientCA != "":
+			log.Fatal("client_ca needs tls_cert and tls_key")
+		default:
+			slog.Warn("listening on TCP without TLS", "addr", addr)
+		}
+	}
+	if cfg.Auth == "off" {
+		slog.Warn("authentication is off: every caller can use every schedule")
 	}
 
-	grpcServer := grpc.NewServer()
-	s := server.NewSchedulerServer("data/schedules.csv")
+	opts = append(opts, grpc.ChainUnaryInterceptor(unaries...), grpc.ChainStreamInterceptor(streams...))
+	grpcServer := grpc.NewServer(opts...)
+	s := server.NewServer(st,
+		server.WithNotifier(notifier),
+		server.WithRenotify(cfg.RenotifyInterval, cfg.RenotifyLimit),
+		server.WithGroups(cfg.Groups),
+		server.WithAdmin(cfg.Admin),
+	)
 	schedulepb.RegisterSchedulerServer(grpcServer, s)
 
-	log.Println("Server is running at :50051")
+	n, err := s.Restore(policy)
+	if err != nil {
+		log.Fatalf("failed to restore schedules: %v", err)
+	}
+	slog.Info("restored schedules", "count", n)
+
+	for _, set := range config.Settings(cfg, sources) {
+		slog.Debug("setting", "key", set.Key, "value", set.Value, "source", set.Source)
+	}
+	// Stop cleanly on a signal so the socket file is removed.
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		grpcServer.Stop()
+	}()
+
+	slog.Info("server is running", "addr", lis.Addr().String())
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
-
-}
\ No newline at end of file
+}
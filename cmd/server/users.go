@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/config"
+)
+
+const userUsage = "usage: remindserver user [-config file] [-users file] add|revoke name | list"
+
+// runUserCommand manages the users allowed to call a server run with
+// -auth token. A running server picks the changes up on the next call.
+func runUserCommand(args []string) {
+	configPath := config.PathFromArgs(args)
+	cfg, _, err := config.LoadServer(configPath)
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	fs := flag.NewFlagSet("user", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), userUsage)
+		fs.PrintDefaults()
+	}
+	fs.String("config", configPath, "config file (env REMINDME_CONFIG)")
+	fs.StringVar(&cfg.Users, "users", cfg.UsersPath(), "token file")
+	fs.Parse(args)
+	users := auth.Open(cfg.Users)
+
+	switch cmd, name := fs.Arg(0), fs.Arg(1); {
+	case cmd == "add" && name != "":
+		token, err := users.Add(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("added %s to %s\n\n", name, users.Path())
+		fmt.Println(token)
+		fmt.Printf("\nThe token is shown only once. Give it to %s to save with:\n  remindme login\n", name)
+	case cmd == "revoke" && name != "":
+		if err := users.Revoke(name); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("revoked %s\n", name)
+	case cmd == "list":
+		list, err := users.List()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(list) == 0 {
+			fmt.Println("no users")
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tCREATED")
+		for _, u := range list {
+			fmt.Fprintf(w, "%s\t%s\n", u.Name, u.Created.Local().Format(time.DateTime))
+		}
+		w.Flush()
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,228 @@
+// Package auth authenticates callers by bearer token. Tokens are issued per
+// user by "remindserver user add" and kept in a local file as SHA-256
+// hashes, so the file never holds a usable token. The file is reread when
+// it changes, so users can be added and revoked while the server runs.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenPrefix starts every token, so a leaked one is easy to recognize.
+const TokenPrefix = "rmd_"
+
+var (
+	ErrExists   = errors.New("user already exists")
+	ErrNotFound = errors.New("no such user")
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidName reports whether name can be a user or group name.
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// User is an entry of the user file.
+type User struct {
+	Name    string
+	Created time.Time
+	hash    string
+}
+
+// Users is the user file at a path. It is safe for concurrent use.
+type Users struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	byHash  map[string]string
+	users   []User
+}
+
+// Open returns the user file at path. A missing file has no users.
+func Open(path string) *Users {
+	return &Users{path: path}
+}
+
+// Path is the file the users are kept in.
+func (u *Users) Path() string {
+	return u.path
+}
+
+// Authenticate returns the user the token was issued to.
+func (u *Users) Authenticate(token string) (string, bool, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.refresh(); err != nil {
+		return "", false, err
+	}
+	name, ok := u.byHash[hash(token)]
+	return name, ok, nil
+}
+
+// List returns the users sorted by name.
+func (u *Users) List() ([]User, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.refresh(); err != nil {
+		return nil, err
+	}
+	list := append([]User(nil), u.users...)
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Add creates a user and returns its token. The token is not stored and
+// cannot be shown again.
+func (u *Users) Add(name string) (string, error) {
+	if !ValidName(name) {
+		return "", fmt.Errorf("invalid user name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.refresh(); err != nil {
+		return "", err
+	}
+	for _, x := range u.users {
+		if x.Name == name {
+			return "", fmt.Errorf("%s: %w", name, ErrExists)
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := TokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	users := append(append([]User(nil), u.users...), User{Name: name, Created: time.Now(), hash: hash(token)})
+	if err := u.save(users); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Revoke deletes a user; its token stops working at once.
+func (u *Users) Revoke(name string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.refresh(); err != nil {
+		return err
+	}
+	var users []User
+	for _, x := range u.users {
+		if x.Name != name {
+			users = append(users, x)
+		}
+	}
+	if len(users) == len(u.users) {
+		return fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	return u.save(users)
+}
+
+// refresh rereads the file if it changed since it was last read.
+func (u *Users) refresh() error {
+	fi, err := os.Stat(u.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		u.users, u.byHash, u.modTime, u.size = nil, nil, time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u.byHash != nil && fi.ModTime().Equal(u.modTime) && fi.Size() == u.size {
+		return nil
+	}
+
+	f, err := os.Open(u.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return fmt.Errorf("%s: %v", u.path, err)
+	}
+	users := make([]User, 0, len(records))
+	byHash := make(map[string]string, len(records))
+	for _, r := range records {
+		if len(r) != 3 {
+			return fmt.Errorf("%s: malformed line %q", u.path, strings.Join(r, ","))
+		}
+		created, _ := time.Parse(time.RFC3339, r[2])
+		users = append(users, User{Name: r[0], hash: r[1], Created: created})
+		byHash[r[1]] = r[0]
+	}
+	u.users, u.byHash, u.modTime, u.size = users, byHash, fi.ModTime(), fi.Size()
+	return nil
+}
+
+// save replaces the file, readable only by its owner, and forces the next
+// refresh to read it back.
+func (u *Users) save(users []User) error {
+	if err := os.MkdirAll(filepath.Dir(u.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(u.path), ".users-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	w := csv.NewWriter(tmp)
+	for _, x := range users {
+		w.Write([]string{x.Name, x.hash, x.Created.Format(time.RFC3339)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), u.path); err != nil {
+		return err
+	}
+	u.byHash = nil
+	return u.refresh()
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type userKey struct{}
+
+// WithUser returns a context carrying the authenticated user.
+func WithUser(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, userKey{}, name)
+}
+
+// UserFrom returns the authenticated user of a request. It reports false
+// when authentication is off.
+func UserFrom(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(userKey{}).(string)
+	return name, ok
+}
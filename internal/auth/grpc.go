@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Interceptors authenticate every call by the bearer token in its
+// "authorization" metadata and attach the user to the context. With no
+// users every call is refused.
+func Interceptors(users *Users) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	authenticate := func(ctx context.Context) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		token, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+		}
+		name, ok, err := users.Authenticate(strings.TrimSpace(token))
+		if err != nil {
+			slog.Error("failed to read users", "path", users.Path(), "err", err)
+			return nil, status.Error(codes.Internal, "cannot read users")
+		}
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid or revoked token")
+		}
+		return WithUser(ctx, name), nil
+	}
+
+	unary := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ss, ctx})
+	}
+	return unary, stream
+}
+
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// Token returns per-call credentials that send token as a bearer token.
+// gRPC only sends them over TLS or a local connection.
+func Token(token string) credentials.PerRPCCredentials {
+	return bearer(token)
+}
+
+type bearer string
+
+func (b bearer) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (bearer) RequireTransportSecurity() bool {
+	return true
+}
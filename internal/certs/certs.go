@@ -0,0 +1,257 @@
+// Package certs sets up TLS between the client and the server and issues
+// the certificates for a small deployment: a local CA, a server
+// certificate and one client certificate per member.
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/auth"
+)
+
+// File names written by Init, relative to its directory.
+const (
+	CAFile    = "ca.pem"
+	CAKeyFile = "ca-key.pem"
+)
+
+// caValid is the shortest lifetime of a new CA, so certificates can be
+// reissued for years before the CA has to be replaced.
+const caValid = 10 * 365 * 24 * time.Hour
+
+// CertFile and KeyFile are the files of the certificate issued to name.
+func CertFile(name string) string { return name + ".pem" }
+func KeyFile(name string) string  { return name + "-key.pem" }
+
+// ServerConfig loads the server's certificate and key. With a clientCA,
+// clients must present a certificate signed by it (mutual TLS).
+func ServerConfig(certFile, keyFile, clientCA string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if clientCA != "" {
+		pool, err := loadPool(clientCA)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// ClientConfig trusts the CA bundle at caFile, or the system roots if it is
+// "system", and presents the certificate in certFile if one is given.
+func ClientConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "system" {
+		pool, err := loadPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" {
+		if keyFile == "" {
+			return nil, errors.New("a client certificate needs its key")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+func loadPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("%s: no PEM certificates", path)
+	}
+	return pool, nil
+}
+
+// Request describes the certificates Init issues.
+type Request struct {
+	Dir     string
+	Hosts   []string // DNS names and IP addresses of the server
+	Clients []string // one client certificate per name
+	Valid   time.Duration
+	Force   bool // overwrite existing server and client certificates
+}
+
+// Init issues a server certificate for r.Hosts and a client certificate
+// for each of r.Clients, signed by the CA in r.Dir. The CA is created on
+// the first run, valid for at least ten years, and reused afterwards, so
+// members can be added later. It returns the files it wrote.
+func Init(r Request) ([]string, error) {
+	if len(r.Hosts) == 0 {
+		return nil, errors.New("the server certificate needs at least one host")
+	}
+	for _, c := range r.Clients {
+		// Names become file names, so they follow the user name rule.
+		if !auth.ValidName(c) {
+			return nil, fmt.Errorf("invalid client name %q: use letters, digits, '.', '_' and '-'", c)
+		}
+		// These would overwrite the CA, the server's or another key.
+		if c == "ca" || c == "server" || strings.HasSuffix(c, "-key") {
+			return nil, fmt.Errorf("%q is reserved", c)
+		}
+	}
+	if err := os.MkdirAll(r.Dir, 0o700); err != nil {
+		return nil, err
+	}
+	var written []string
+	ca, caKey, err := loadCA(r.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		ca, caKey, err = newCA(r.Dir, max(r.Valid, caValid))
+		written = append(written, filepath.Join(r.Dir, CAFile), filepath.Join(r.Dir, CAKeyFile))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type leaf struct {
+		name  string
+		hosts []string
+		usage x509.ExtKeyUsage
+	}
+	leaves := []leaf{{"server", r.Hosts, x509.ExtKeyUsageServerAuth}}
+	for _, c := range r.Clients {
+		leaves = append(leaves, leaf{c, nil, x509.ExtKeyUsageClientAuth})
+	}
+	for _, l := range leaves {
+		certPath := filepath.Join(r.Dir, CertFile(l.name))
+		if _, err := os.Stat(certPath); err == nil && !r.Force {
+			// Keep what was issued before; rerunning with new names only
+			// adds members.
+			continue
+		}
+		if err := issue(r.Dir, l.name, l.hosts, l.usage, r.Valid, ca, caKey); err != nil {
+			return written, err
+		}
+		written = append(written, certPath, filepath.Join(r.Dir, KeyFile(l.name)))
+	}
+	return written, nil
+}
+
+func newCA(dir string, valid time.Duration) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl, err := template("remindme CA", valid)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := write(dir, CAFile, CAKeyFile, der, key); err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	return ca, key, err
+}
+
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	pair, err := tls.LoadX509KeyPair(filepath.Join(dir, CAFile), filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok || !ca.IsCA {
+		return nil, nil, fmt.Errorf("%s is not a CA", filepath.Join(dir, CAFile))
+	}
+	return ca, signer, nil
+}
+
+func issue(dir, name string, hosts []string, usage x509.ExtKeyUsage, valid time.Duration, ca *x509.Certificate, caKey crypto.Signer) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template(name, valid)
+	if err != nil {
+		return err
+	}
+	if tmpl.NotAfter.After(ca.NotAfter) {
+		// A certificate is no good once its CA expires.
+		tmpl.NotAfter = ca.NotAfter
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, key.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	return write(dir, CertFile(name), KeyFile(name), der, key)
+}
+
+func template(cn string, valid time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"remindme"}},
+		// Allow for clocks that are a little behind.
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(valid),
+	}, nil
+}
+
+// write saves a certificate, readable by anyone, and its key, readable
+// only by the owner.
+func write(dir, certName, keyName string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(filepath.Join(dir, keyName), keyPEM, 0o600); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, certName), certPEM, 0o644)
+}
@@ -0,0 +1,357 @@
+// Package config layers the settings of the server and the client. Each
+// setting starts at its default and is overridden in turn by the config
+// file, a REMINDME_* environment variable and a command-line flag.
+//
+// The file is YAML with a section per program:
+//
+//	server:
+//	  listen: ":50051"
+//	  data: /home/alice/.remindme/schedules.csv
+//	  notifier: freedesktop
+//	  notifier_opts: {urgency: critical}
+//	  log_level: info
+//	  tz: Asia/Seoul
+//	  tls_cert: /etc/remindme/server.pem
+//	  tls_key: /etc/remindme/server-key.pem
+//	  client_ca: /etc/remindme/ca.pem
+//	  auth: token
+//	  users: /var/lib/remindme/users.csv
+//	  admin: alice
+//	  groups: {ops: [alice, bob]}
+//	client:
+//	  server: remind.example.com:50051
+//	  ca: /home/alice/.config/remindme/ca.pem
+//	  cert: /home/alice/.config/remindme/alice.pem
+//	  key: /home/alice/.config/remindme/alice-key.pem
+//	  token_file: /home/alice/.config/remindme/credentials
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/unixsock"
+	"gopkg.in/yaml.v3"
+)
+
+// Server holds the settings of remindserver. Data defaults by Store.
+// Listen is a TCP address or "unix:" and a socket path; AllowUIDs are the
+// users besides the server's own that may use a socket. A TCP listener
+// uses TLS when TLSCert is set and requires client certificates signed by
+// ClientCA when that is set too. Auth is "token" to require a bearer token
+// from Users, the token file of "remindserver user" next to Data by
+// default, or "off". Admin is the user who owns the schedules added while
+// authentication was off. Groups name the teams schedules can be assigned
+// to as "@name".
+type Server struct {
+	Listen           string              `yaml:"listen" env:"REMINDME_LISTEN"`
+	AllowUIDs        []int               `yaml:"allow_uids" env:"REMINDME_ALLOW_UIDS"`
+	Store            string              `yaml:"store" env:"REMINDME_STORE"`
+	Data             string              `yaml:"data" env:"REMINDME_DATA"`
+	Missed           string              `yaml:"missed" env:"REMINDME_MISSED"`
+	RenotifyInterval time.Duration       `yaml:"renotify_interval" env:"REMINDME_RENOTIFY_INTERVAL"`
+	RenotifyLimit    int                 `yaml:"renotify_limit" env:"REMINDME_RENOTIFY_LIMIT"`
+	Notifier         string              `yaml:"notifier" env:"REMINDME_NOTIFIER"`
+	NotifierOpts     map[string]string   `yaml:"notifier_opts"`
+	LogLevel         string              `yaml:"log_level" env:"REMINDME_LOG_LEVEL"`
+	TZ               string              `yaml:"tz" env:"REMINDME_TZ"`
+	TLSCert          string              `yaml:"tls_cert" env:"REMINDME_TLS_CERT"`
+	TLSKey           string              `yaml:"tls_key" env:"REMINDME_TLS_KEY"`
+	ClientCA         string              `yaml:"client_ca" env:"REMINDME_CLIENT_CA"`
+	Auth             string              `yaml:"auth" env:"REMINDME_AUTH"`
+	Users            string              `yaml:"users" env:"REMINDME_USERS"`
+	Admin            string              `yaml:"admin" env:"REMINDME_ADMIN"`
+	Groups           map[string][]string `yaml:"groups"`
+}
+
+// Client holds the settings of the remindme command. An empty Server is
+// the local socket if a server is listening on it, else localhost:50051.
+// TCP connections use TLS when CA, a bundle path or "system", is set; Cert
+// and Key are presented to servers that require client certificates.
+// Token is the credential file holding the token "remindme login" saved.
+type Client struct {
+	Server string `yaml:"server" env:"REMINDME_SERVER"`
+	TZ     string `yaml:"tz" env:"REMINDME_TZ"`
+	CA     string `yaml:"ca" env:"REMINDME_CA"`
+	Cert   string `yaml:"cert" env:"REMINDME_CLIENT_CERT"`
+	Key    string `yaml:"key" env:"REMINDME_CLIENT_KEY"`
+	Token  string `yaml:"token_file" env:"REMINDME_TOKEN_FILE"`
+}
+
+// DefaultServer returns the built-in server settings.
+func DefaultServer() Server {
+	return Server{
+		Listen:           unixsock.Scheme + unixsock.DefaultPath(),
+		Store:            "csv",
+		Missed:           "fire",
+		RenotifyInterval: 5 * time.Minute,
+		RenotifyLimit:    3,
+		LogLevel:         "info",
+		Auth:             "off",
+	}
+}
+
+// DataPath is Data, or the default file of Store.
+func (c Server) DataPath() string {
+	if c.Data != "" {
+		return c.Data
+	}
+	if c.Store == "sqlite" {
+		return "data/schedules.db"
+	}
+	return "data/schedules.csv"
+}
+
+// UsersPath is Users, or users.csv next to the schedules.
+func (c Server) UsersPath() string {
+	if c.Users != "" {
+		return c.Users
+	}
+	return filepath.Join(filepath.Dir(c.DataPath()), "users.csv")
+}
+
+// DefaultClient returns the built-in client settings.
+func DefaultClient() Client {
+	c := Client{}
+	if dir, err := os.UserConfigDir(); err == nil {
+		c.Token = filepath.Join(dir, "remindme", "credentials")
+	}
+	return c
+}
+
+// Target is the gRPC target the client dials.
+func (c Client) Target() string {
+	if c.Server != "" {
+		return c.Server
+	}
+	if t := unixsock.Discover(); t != "" {
+		return t
+	}
+	return "localhost:50051"
+}
+
+// Sources records which layer set each setting, keyed by its YAML name:
+// "default", "file", "env" or "flag".
+type Sources map[string]string
+
+// file is the layout of the config file.
+type file struct {
+	Server map[string]any `yaml:"server"`
+	Client map[string]any `yaml:"client"`
+}
+
+// DefaultPath is $REMINDME_CONFIG, or remindme/config.yaml in the user's
+// config directory.
+func DefaultPath() string {
+	if p := os.Getenv("REMINDME_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "remindme", "config.yaml")
+}
+
+// PathFromArgs finds a -config or --config flag in args so the file can be
+// read before the other flags are defined. Every flag before the first
+// argument is taken to have a value, as none of the global flags are
+// booleans. It returns DefaultPath if there is none.
+func PathFromArgs(args []string) string {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" || !strings.HasPrefix(a, "-") {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		if hasValue {
+			if name == "config" {
+				return value
+			}
+			continue
+		}
+		i++
+		if name == "config" && i < len(args) {
+			return args[i]
+		}
+	}
+	return DefaultPath()
+}
+
+// LoadServer layers the defaults, the server section of the file at path
+// and the environment. A missing file is not an error.
+func LoadServer(path string) (Server, Sources, error) {
+	cfg := DefaultServer()
+	src, err := load(path, "server", &cfg)
+	return cfg, src, err
+}
+
+// LoadClient layers the defaults, the client section of the file at path
+// and the environment. A missing file is not an error.
+func LoadClient(path string) (Client, Sources, error) {
+	cfg := DefaultClient()
+	src, err := load(path, "client", &cfg)
+	return cfg, src, err
+}
+
+func load(path, section string, cfg any) (Sources, error) {
+	v := reflect.ValueOf(cfg).Elem()
+	src := Sources{}
+	for i := 0; i < v.NumField(); i++ {
+		src[v.Type().Field(i).Tag.Get("yaml")] = "default"
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		var f file
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		values := f.Server
+		if section == "client" {
+			values = f.Client
+		}
+		// Re-encode the section so yaml decodes it into the typed struct,
+		// then copy only the keys the file set.
+		raw, err := yaml.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		fromFile := reflect.New(v.Type())
+		if err := yaml.Unmarshal(raw, fromFile.Interface()); err != nil {
+			return nil, fmt.Errorf("%s: %s: %v", path, section, err)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			key := v.Type().Field(i).Tag.Get("yaml")
+			if _, ok := values[key]; ok {
+				v.Field(i).Set(fromFile.Elem().Field(i))
+				src[key] = "file"
+			}
+		}
+		for key := range values {
+			if _, ok := src[key]; !ok {
+				return nil, fmt.Errorf("%s: %s: unknown setting %q", path, section, key)
+			}
+		}
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("env")
+		value, ok := os.LookupEnv(name)
+		if name == "" || !ok {
+			continue
+		}
+		if err := setString(v.Field(i), value); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		src[field.Tag.Get("yaml")] = "env"
+	}
+	return src, nil
+}
+
+func setString(f reflect.Value, s string) error {
+	switch {
+	case f.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+	case f.Kind() == reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(n))
+	case f.Kind() == reflect.String:
+		f.SetString(s)
+	case f.Type() == reflect.TypeOf([]int(nil)):
+		var list []int
+		for _, part := range strings.Split(s, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return err
+			}
+			list = append(list, n)
+		}
+		f.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("cannot be set from the environment")
+	}
+	return nil
+}
+
+// FlagName is the command-line flag for a setting's YAML name.
+func FlagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// MarkFlags records the settings that were given on the command line.
+func (s Sources) MarkFlags(fs *flag.FlagSet) {
+	fs.Visit(func(f *flag.Flag) {
+		key := strings.ReplaceAll(f.Name, "-", "_")
+		if _, ok := s[key]; ok {
+			s[key] = "flag"
+		}
+	})
+}
+
+// Setting is one effective value, for display.
+type Setting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Settings lists the fields of cfg, a Server or Client, with their
+// sources in declaration order. Options whose names mention a password,
+// secret or token are shown as "***".
+func Settings(cfg any, src Sources) []Setting {
+	v := reflect.ValueOf(cfg)
+	var out []Setting
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("yaml")
+		out = append(out, Setting{Key: key, Value: format(v.Field(i)), Source: src[key]})
+	}
+	return out
+}
+
+func format(f reflect.Value) string {
+	if f.Kind() != reflect.Map {
+		return fmt.Sprint(f.Interface())
+	}
+	var parts []string
+	for _, k := range f.MapKeys() {
+		value := fmt.Sprint(f.MapIndex(k))
+		if secret(fmt.Sprint(k)) {
+			value = "***"
+		}
+		parts = append(parts, fmt.Sprintf("%v=%s", k, value))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// secret reports whether an option named key holds a credential.
+func secret(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range []string{"password", "secret", "token"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
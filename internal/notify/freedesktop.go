@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func init() {
+	Register("freedesktop", newFreedesktop)
+}
+
+// freedesktopNotifier talks to org.freedesktop.Notifications through
+// notify-send. When the reminder has a URL, an "open" action is attached
+// and the URL is opened with xdg-open once the user clicks it.
+type freedesktopNotifier struct {
+	appName string
+	urgency string
+}
+
+func newFreedesktop(opts Options) (Notifier, error) {
+	n := &freedesktopNotifier{appName: "remindme", urgency: "normal"}
+	if v := opts["app_name"]; v != "" {
+		n.appName = v
+	}
+	if v := opts["urgency"]; v != "" {
+		switch v {
+		case "low", "normal", "critical":
+			n.urgency = v
+		default:
+			return nil, fmt.Errorf("freedesktop: invalid urgency %q", v)
+		}
+	}
+	return n, nil
+}
+
+func (f *freedesktopNotifier) Notify(n Notification) error {
+	body := n.Memo
+	if n.URL != "" {
+		body = strings.TrimSpace(body + "\n" + n.URL)
+	}
+	args := []string{"--app-name=" + f.appName, "--urgency=" + f.urgency}
+	if n.URL == "" {
+		// "--" keeps a title or memo starting with "-" from being read as
+		// an option.
+		args = append(args, "--", n.Title, body)
+		return exec.Command("notify-send", args...).Run()
+	}
+
+	// --wait keeps notify-send alive until the notification is closed and
+	// prints the name of the invoked action, so it runs in the background.
+	args = append(args, "--action=open=열기", "--wait", "--", n.Title, body)
+	cmd := exec.Command("notify-send", args...)
+	var out strings.Builder
+	cmd.Stdout = &out
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			return
+		}
+		if strings.TrimSpace(out.String()) == "open" {
+			exec.Command("xdg-open", n.URL).Run()
+		}
+	}()
+	return nil
+}
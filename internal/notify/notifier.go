@@ -1,17 +1,115 @@
 package notify
 
 import (
-	"os/exec"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
-func Send(title, memo, url string) error {
-	args := []string {"-title", title}
-	if memo != "" {
-		args = append(args, "-message", memo)
+// Notification is what a backend receives when a reminder fires. Time is
+// when it fired; To are the schedule's own email recipients, if any.
+type Notification struct {
+	ID    string
+	Title string
+	Memo  string
+	URL   string
+	Time  time.Time
+	To    []string
+}
+
+// Notifier delivers a fired reminder to the user.
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// Options carries backend specific settings, usually taken straight from
+// the server configuration.
+type Options map[string]string
+
+// Factory builds a backend from its options.
+type Factory func(opts Options) (Notifier, error)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Factory{}
+)
+
+// Register makes a backend available under name. Backends register
+// themselves from init.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("notify: Register called twice for backend " + name)
+	}
+	registry[name] = f
+}
+
+// New builds the backend registered under name. A comma-separated list of
+// names builds each of them, and every notification goes to all of them.
+func New(name string, opts Options) (Notifier, error) {
+	if strings.Contains(name, ",") {
+		var m multi
+		for _, part := range strings.Split(name, ",") {
+			n, err := New(strings.TrimSpace(part), opts)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, n)
+		}
+		return m, nil
 	}
-	if url != "" {
-		args = append(args, "-open", url)
+	mu.RLock()
+	f, ok := registry[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown notifier %q (available: %v)", name, Names())
 	}
-	cmd := exec.Command("terminal-notifier", args...)
-	return cmd.Run()
-}
\ No newline at end of file
+	return f(opts)
+}
+
+// multi sends each notification to several backends.
+type multi []Notifier
+
+func (m multi) Notify(n Notification) error {
+	var errs []error
+	for _, b := range m {
+		if err := b.Notify(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Names lists the registered backends.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the backend that suits the current OS.
+func Default() string {
+	if runtime.GOOS == "darwin" {
+		return "terminal-notifier"
+	}
+	return "freedesktop"
+}
+
+// randomID returns 32 random hex digits, for delivery and message IDs.
+func randomID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,215 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"html"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("smtp", newSMTP)
+}
+
+// smtpNotifier mails fired reminders as plain text with an HTML
+// alternative. A schedule's own recipients replace the smtp_to default.
+type smtpNotifier struct {
+	host     string
+	port     int
+	startTLS bool
+	username string
+	password string
+	from     *mail.Address
+	to       []string
+	timeout  time.Duration
+}
+
+func newSMTP(opts Options) (Notifier, error) {
+	n := &smtpNotifier{
+		host:     opts["smtp_host"],
+		port:     587,
+		startTLS: true,
+		username: opts["smtp_username"],
+		password: opts["smtp_password"],
+		timeout:  30 * time.Second,
+	}
+	if n.host == "" {
+		return nil, fmt.Errorf("smtp: smtp_host is required")
+	}
+	if v := opts["smtp_port"]; v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p <= 0 || p > 65535 {
+			return nil, fmt.Errorf("smtp: invalid smtp_port %q", v)
+		}
+		n.port = p
+	}
+	if v := opts["smtp_starttls"]; v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("smtp: invalid smtp_starttls %q", v)
+		}
+		n.startTLS = b
+	}
+	if v := opts["smtp_timeout"]; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("smtp: invalid smtp_timeout %q", v)
+		}
+		n.timeout = d
+	}
+	from, err := mail.ParseAddress(opts["smtp_from"])
+	if err != nil {
+		return nil, fmt.Errorf("smtp: invalid smtp_from %q", opts["smtp_from"])
+	}
+	n.from = from
+	if v := opts["smtp_to"]; v != "" {
+		list, err := mail.ParseAddressList(v)
+		if err != nil {
+			return nil, fmt.Errorf("smtp: invalid smtp_to: %v", err)
+		}
+		for _, a := range list {
+			n.to = append(n.to, a.Address)
+		}
+	}
+	return n, nil
+}
+
+func (s *smtpNotifier) Notify(n Notification) error {
+	to := n.To
+	if len(to) == 0 {
+		to = s.to
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("smtp: no recipients for %s; set smtp_to or the schedule's emails", n.ID)
+	}
+	msg, err := s.message(n, to)
+	if err != nil {
+		return err
+	}
+	// Slow mail servers must not hold up the scheduler.
+	go func() {
+		if err := s.send(to, msg); err != nil {
+			slog.Error("smtp delivery failed", "id", n.ID, "to", to, "err", err)
+		}
+	}()
+	return nil
+}
+
+// send delivers msg over one connection, upgrading it with STARTTLS
+// before authenticating when that is enabled.
+func (s *smtpNotifier) send(to []string, msg []byte) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	conn, err := net.DialTimeout("tcp", addr, s.timeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(s.timeout))
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if s.startTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not support STARTTLS; set smtp_starttls=false to send in the clear", addr)
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message renders n as a multipart/alternative mail with CRLF line
+// endings.
+func (s *smtpNotifier) message(n Notification, to []string) ([]byte, error) {
+	fired := n.Time
+	if fired.IsZero() {
+		fired = time.Now()
+	}
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	text := n.Title + "\r\n"
+	if n.Memo != "" {
+		text += "\r\n" + n.Memo + "\r\n"
+	}
+	if n.URL != "" {
+		text += "\r\n" + n.URL + "\r\n"
+	}
+	page := "<p><strong>" + html.EscapeString(n.Title) + "</strong></p>\r\n"
+	if n.Memo != "" {
+		page += "<p>" + strings.ReplaceAll(html.EscapeString(n.Memo), "\n", "<br>\r\n") + "</p>\r\n"
+	}
+	if n.URL != "" {
+		u := html.EscapeString(n.URL)
+		page += `<p><a href="` + u + `">` + u + "</a></p>\r\n"
+	}
+	for _, part := range []struct{ typ, content string }{{"text/plain", text}, {"text/html", page}} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.typ + "; charset=UTF-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		qp.Write([]byte(part.content))
+		qp.Close()
+	}
+	mw.Close()
+
+	var msg bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&msg, "%s: %s\r\n", key, value)
+	}
+	header("From", s.from.String())
+	header("To", strings.Join(to, ", "))
+	// Titles are single-line, but a stray newline must not start a header.
+	subject := strings.Join(strings.Fields(n.Title), " ")
+	header("Subject", mime.QEncoding.Encode("UTF-8", subject))
+	header("Date", fired.Format(time.RFC1123Z))
+	header("Message-ID", "<"+randomID()+"@"+s.host+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	header("X-Remindme-ID", n.ID)
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"os/exec"
+)
+
+func init() {
+	Register("terminal-notifier", func(Options) (Notifier, error) {
+		return terminalNotifier{}, nil
+	})
+}
+
+// terminalNotifier shows a macOS notification through terminal-notifier.
+type terminalNotifier struct{}
+
+func (terminalNotifier) Notify(n Notification) error {
+	args := []string{"-title", n.Title}
+	if n.Memo != "" {
+		args = append(args, "-message", n.Memo)
+	}
+	if n.URL != "" {
+		args = append(args, "-open", n.URL)
+	}
+	cmd := exec.Command("terminal-notifier", args...)
+	return cmd.Run()
+}
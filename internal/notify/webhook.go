@@ -0,0 +1,229 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("webhook", newWebhook)
+}
+
+// Headers sent with every webhook delivery. The signature is the hex
+// HMAC-SHA256, keyed by the webhook_secret option, of the timestamp, a dot
+// and the body, so receivers can reject both forged and replayed requests.
+const (
+	WebhookSignatureHeader = "X-Remindme-Signature"
+	WebhookTimestampHeader = "X-Remindme-Timestamp"
+	WebhookDeliveryHeader  = "X-Remindme-Delivery"
+)
+
+// WebhookPayload is the JSON body POSTed for a fired reminder.
+type WebhookPayload struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Memo     string `json:"memo"`
+	URL      string `json:"url"`
+	FireTime string `json:"fire_time"`
+}
+
+// DeadLetter is the record kept for a delivery that failed for good, one
+// JSON object per line of the webhook_dead_letter file.
+type DeadLetter struct {
+	Time     string          `json:"time"`
+	Endpoint string          `json:"endpoint"`
+	Delivery string          `json:"delivery"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// webhookNotifier POSTs fired reminders to HTTP endpoints. Each endpoint
+// is delivered to in the background, retrying with exponential backoff on
+// network errors, 429 and 5xx responses.
+type webhookNotifier struct {
+	endpoints  []string
+	secret     []byte
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	deadLetter string
+	client     *http.Client
+
+	mu sync.Mutex // serializes writes to deadLetter
+}
+
+func newWebhook(opts Options) (Notifier, error) {
+	w := &webhookNotifier{
+		secret:     []byte(opts["webhook_secret"]),
+		timeout:    10 * time.Second,
+		retries:    5,
+		backoff:    time.Second,
+		deadLetter: opts["webhook_dead_letter"],
+		client:     &http.Client{},
+	}
+	for _, e := range strings.Split(opts["webhook_urls"], ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		u, err := url.Parse(e)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook: invalid URL %q", e)
+		}
+		w.endpoints = append(w.endpoints, e)
+	}
+	if len(w.endpoints) == 0 {
+		return nil, fmt.Errorf("webhook: webhook_urls is required")
+	}
+	for key, dst := range map[string]*time.Duration{"webhook_timeout": &w.timeout, "webhook_backoff": &w.backoff} {
+		if v := opts[key]; v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("webhook: invalid %s %q", key, v)
+			}
+			*dst = d
+		}
+	}
+	if v := opts["webhook_retries"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("webhook: invalid webhook_retries %q", v)
+		}
+		w.retries = n
+	}
+	return w, nil
+}
+
+// maxBackoff caps the wait between two attempts.
+const maxBackoff = 5 * time.Minute
+
+func (w *webhookNotifier) Notify(n Notification) error {
+	fired := n.Time
+	if fired.IsZero() {
+		fired = time.Now()
+	}
+	body, err := json.Marshal(WebhookPayload{
+		ID:       n.ID,
+		Title:    n.Title,
+		Memo:     n.Memo,
+		URL:      n.URL,
+		FireTime: fired.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	// Retries can take minutes, so they must not hold up the scheduler.
+	for _, endpoint := range w.endpoints {
+		go w.deliver(endpoint, randomID(), body)
+	}
+	return nil
+}
+
+// deliver sends body to endpoint until it is accepted, the error is
+// permanent or the retries run out, then records a dead letter.
+func (w *webhookNotifier) deliver(endpoint, delivery string, body []byte) {
+	wait := w.backoff
+	var attempts int
+	var err error
+	for attempts = 1; ; attempts++ {
+		var retry bool
+		retry, err = w.post(endpoint, delivery, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempts > w.retries {
+			break
+		}
+		slog.Debug("webhook delivery failed, retrying", "endpoint", endpoint, "attempt", attempts, "in", wait, "err", err)
+		time.Sleep(wait)
+		wait = min(wait*2, maxBackoff)
+	}
+	w.bury(DeadLetter{
+		Time:     time.Now().Format(time.RFC3339),
+		Endpoint: endpoint,
+		Delivery: delivery,
+		Attempts: attempts,
+		Error:    err.Error(),
+		Payload:  body,
+	})
+}
+
+// post makes one attempt and reports whether a failure is worth retrying.
+func (w *webhookNotifier) post(endpoint, delivery string, body []byte) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "remindme")
+	req.Header.Set(WebhookDeliveryHeader, delivery)
+	req.Header.Set(WebhookTimestampHeader, ts)
+	if len(w.secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+WebhookSignature(w.secret, ts, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("%s", resp.Status)
+	default:
+		return false, fmt.Errorf("%s", resp.Status)
+	}
+}
+
+// bury appends d to the dead-letter file, or logs it when there is none.
+func (w *webhookNotifier) bury(d DeadLetter) {
+	slog.Error("webhook delivery failed", "endpoint", d.Endpoint, "delivery", d.Delivery, "attempts", d.Attempts, "err", d.Error)
+	if w.deadLetter == "" {
+		slog.Error("webhook dead letter", "payload", string(d.Payload))
+		return
+	}
+	line, _ := json.Marshal(d)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	f, err := os.OpenFile(w.deadLetter, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err == nil {
+		_, err = f.Write(append(line, '\n'))
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		slog.Error("failed to write webhook dead letter", "path", w.deadLetter, "err", err, "payload", string(d.Payload))
+	}
+}
+
+// WebhookSignature is the hex HMAC-SHA256 of timestamp, ".", body.
+// Receivers compare it with the sha256= value of the signature header.
+func WebhookSignature(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
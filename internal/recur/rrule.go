@@ -0,0 +1,324 @@
+// Package recur implements the subset of iCalendar RRULE (RFC 5545) used by
+// recurring reminders: FREQ=DAILY|WEEKLY|MONTHLY|YEARLY with INTERVAL,
+// BYDAY (weekly only), BYMONTHDAY (monthly only), UNTIL and COUNT.
+package recur
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/zone"
+)
+
+type Freq int
+
+const (
+	Daily Freq = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var freqNames = map[Freq]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+var dayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// maxPeriods bounds the search for the next occurrence so that a rule that
+// can never match (e.g. BYMONTHDAY=31 every 12 months from April) ends.
+const maxPeriods = 10000
+
+// Rule is a parsed recurrence rule. The first occurrence is always the
+// schedule's own Datetime, which acts as DTSTART.
+type Rule struct {
+	Freq       Freq
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Until      time.Time
+	Count      int
+}
+
+// Parse reads an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// An "RRULE:" prefix is accepted, and daily, weekly, monthly and yearly may
+// be used in place of FREQ=..., as in "weekly;COUNT=4".
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "rrule:")
+
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		switch strings.ToLower(part) {
+		case "daily", "weekly", "monthly", "yearly":
+			part = "FREQ=" + part
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("rrule: malformed part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = 0
+			for f, name := range freqNames {
+				if strings.EqualFold(value, name) {
+					r.Freq = f
+				}
+			}
+			if r.Freq == 0 {
+				return nil, fmt.Errorf("rrule: unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("rrule: invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := parseWeekday(d)
+				if !ok {
+					return nil, fmt.Errorf("rrule: invalid BYDAY %q", d)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil || n == 0 || n > 31 || n < -31 {
+					return nil, fmt.Errorf("rrule: invalid BYMONTHDAY %q", d)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "UNTIL":
+			t, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = t
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("rrule: invalid COUNT %q", value)
+			}
+			r.Count = n
+		default:
+			return nil, fmt.Errorf("rrule: unsupported part %q", key)
+		}
+	}
+
+	if r.Freq == 0 {
+		return nil, fmt.Errorf("rrule: FREQ is required")
+	}
+	if len(r.ByDay) > 0 && r.Freq != Weekly {
+		return nil, fmt.Errorf("rrule: BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != Monthly {
+		return nil, fmt.Errorf("rrule: BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("rrule: UNTIL and COUNT are mutually exclusive")
+	}
+	return r, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for i, name := range dayNames {
+		if strings.EqualFold(strings.TrimSpace(s), name) {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+func parseUntil(s string) (time.Time, error) {
+	loc := time.Local
+	if strings.HasSuffix(s, "Z") {
+		loc = time.UTC
+		s = strings.TrimSuffix(s, "Z")
+	}
+	for _, layout := range []string{"20060102T150405", "20060102T1504", "20060102"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			if layout == "20060102" {
+				// A bare date includes the whole day.
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("rrule: invalid UNTIL %q", s)
+}
+
+// String formats the rule back into RRULE syntax.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + freqNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = dayNames[d]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if !r.Until.IsZero() {
+		if r.Until.Location() == time.UTC {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405")+"Z")
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405"))
+		}
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Advance returns the first occurrence strictly after the given time,
+// counting start as the first occurrence. The returned rule has its COUNT
+// reduced so that it describes the remaining series when the returned time
+// is used as the new start. ok is false when the series has ended.
+func (r *Rule) Advance(start, after time.Time) (next time.Time, rest *Rule, ok bool) {
+	n := 0
+	r.each(start, func(t time.Time) bool {
+		n++
+		if t.After(after) {
+			next, ok = t, true
+			return false
+		}
+		return true
+	})
+	if !ok {
+		return time.Time{}, nil, false
+	}
+	cp := *r
+	if cp.Count > 0 {
+		cp.Count -= n - 1
+	}
+	return next, &cp, true
+}
+
+// Between returns the occurrences in [from, to), counting start as the
+// first occurrence.
+func (r *Rule) Between(start, from, to time.Time) []time.Time {
+	var out []time.Time
+	r.each(start, func(t time.Time) bool {
+		if !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return true
+	})
+	return out
+}
+
+// each yields occurrences in chronological order until yield returns false
+// or the series ends.
+func (r *Rule) each(start time.Time, yield func(time.Time) bool) {
+	until := r.Until
+	if !until.IsZero() && until.Location() != time.UTC {
+		// UNTIL without Z is a wall time in the zone of start.
+		until = zone.Date(until.Year(), until.Month(), until.Day(), until.Hour(), until.Minute(), until.Second(), start.Location())
+	}
+	n := 0
+	for k := 0; k < maxPeriods; k++ {
+		for _, t := range r.period(start, k*r.Interval) {
+			if t.Before(start) {
+				continue
+			}
+			n++
+			if r.Count > 0 && n > r.Count {
+				return
+			}
+			if !until.IsZero() && t.After(until) {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// period returns the sorted, distinct candidate occurrences in the k-th period after
+// the one containing start. Occurrences keep the wall-clock time of start
+// in its location; see zone.Date for how DST transitions are resolved.
+func (r *Rule) period(start time.Time, k int) []time.Time {
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	loc := start.Location()
+
+	switch r.Freq {
+	case Daily:
+		return []time.Time{zone.Date(y, m, d+k, hh, mm, ss, loc)}
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Weeks start on Monday, as RRULE's default WKST=MO.
+		monday := d - (int(start.Weekday())+6)%7 + 7*k
+		var out []time.Time
+		for _, wd := range days {
+			out = append(out, zone.Date(y, m, monday+(int(wd)+6)%7, hh, mm, ss, loc))
+		}
+		return sortUnique(out)
+
+	case Monthly:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{d}
+		}
+		first := time.Date(y, m+time.Month(k), 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1).Day()
+		var out []time.Time
+		for _, md := range days {
+			if md < 0 {
+				md = last + md + 1
+			}
+			// Days that do not exist in this month are skipped, as in RFC 5545.
+			if md < 1 || md > last {
+				continue
+			}
+			out = append(out, zone.Date(first.Year(), first.Month(), md, hh, mm, ss, loc))
+		}
+		return sortUnique(out)
+
+	case Yearly:
+		if time.Date(y+k, m, d, 0, 0, 0, 0, time.UTC).Day() != d {
+			// Feb 29 in a non-leap year.
+			return nil
+		}
+		return []time.Time{zone.Date(y+k, m, d, hh, mm, ss, loc)}
+	}
+	return nil
+}
+
+// sortUnique sorts times and drops repeats, such as BYDAY=MO,MO or
+// BYMONTHDAY=31,-1 in a 31-day month, so each instant counts once.
+func sortUnique(ts []time.Time) []time.Time {
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+	return slices.CompactFunc(ts, time.Time.Equal)
+}
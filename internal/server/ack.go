@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// States of a reminder after it fired. A pending reminder has no state.
+const (
+	StateAwaitingAck = "awaiting_ack"
+	StateSnoozed     = "snoozed"
+)
+
+// ackSuffix marks scheduler entries that re-notify an unacknowledged
+// reminder, as opposed to entries for its Datetime.
+const ackSuffix = "#ack"
+
+func ackKey(id string) string {
+	return id + ackSuffix
+}
+
+// WithRenotify sets how often an unacknowledged reminder is notified again
+// and how many times. A limit of zero disables acknowledgement: fired
+// reminders are dropped right away.
+func WithRenotify(interval time.Duration, limit int) Option {
+	return func(s *ScheduleServer) {
+		s.renotifyInterval = interval
+		s.renotifyLimit = limit
+	}
+}
+
+func (s *ScheduleServer) SnoozeSchedule(ctx context.Context, req *schedulepb.SnoozeRequest) (*schedulepb.ScheduleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, err := time.ParseDuration(req.Duration)
+	if err != nil || d <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid snooze duration %q", req.Duration)
+	}
+	sch, err := s.resolve(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if sch.State == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "schedule %s has not fired", sch.Id)
+	}
+
+	due := time.Now().Add(d)
+	sch.State = StateSnoozed
+	sch.AckDue = due.Format(time.RFC3339)
+	sch.NotifyCount = 0
+	if err := s.store.Update(sch); err != nil {
+		return nil, err
+	}
+	s.sched.Add(ackKey(sch.Id), due)
+	s.events.publish(schedulepb.EventType_EVENT_TYPE_SNOOZED, sch)
+	return &schedulepb.ScheduleResponse{Message: "Snoozed until " + due.Format(time.DateTime)}, nil
+}
+
+func (s *ScheduleServer) AckSchedule(ctx context.Context, req *schedulepb.ScheduleId) (*schedulepb.ScheduleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sch, err := s.resolve(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if sch.State == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "schedule %s has not fired", sch.Id)
+	}
+
+	// A member of a team reminder acknowledges for themself; it is done
+	// once every member has. The owner, or anyone without
+	// authentication, closes it for everyone.
+	user, _ := auth.UserFrom(ctx)
+	members := s.members(sch)
+	if slices.Contains(members, user) {
+		if !slices.Contains(sch.AckedBy, user) {
+			sch.AckedBy = append(sch.AckedBy, user)
+		}
+		s.events.publishAck(sch, user)
+		if waiting := pending(members, sch.AckedBy); len(waiting) > 0 {
+			if err := s.store.Update(sch); err != nil {
+				return nil, err
+			}
+			return &schedulepb.ScheduleResponse{Message: "Acknowledged; waiting for " + strings.Join(waiting, ", ")}, nil
+		}
+	} else {
+		s.events.publishAck(sch, user)
+	}
+
+	s.sched.Remove(ackKey(sch.Id))
+	if err := s.finish(sch); err != nil {
+		return nil, err
+	}
+	return &schedulepb.ScheduleResponse{Message: "Schedule acknowledged."}, nil
+}
+
+// pending lists the members who have not acknowledged yet.
+func pending(members, acked []string) []string {
+	var out []string
+	for _, m := range members {
+		if !slices.Contains(acked, m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// awaitAck puts a reminder that just fired into the awaiting_ack state and
+// arms its re-notification.
+func (s *ScheduleServer) awaitAck(sch *schedulepb.ScheduleRequest, count int32) error {
+	due := time.Now().Add(s.renotifyInterval)
+	sch.State = StateAwaitingAck
+	sch.AckDue = due.Format(time.RFC3339)
+	sch.NotifyCount = count
+	if err := s.store.Update(sch); err != nil {
+		return err
+	}
+	s.sched.Add(ackKey(sch.Id), due)
+	return nil
+}
+
+// renotify runs when the ack timer of a reminder expires. It notifies again
+// until the limit is reached, then gives up and reports the reminder as
+// missed.
+func (s *ScheduleServer) renotify(id string) {
+	sch, err := s.store.Get(id)
+	if err != nil || sch.State == "" {
+		return
+	}
+
+	if int(sch.NotifyCount) > s.renotifyLimit {
+		s.events.publish(schedulepb.EventType_EVENT_TYPE_MISSED, sch)
+		if err := s.finish(sch); err != nil {
+			slog.Error("알림 상태 정리 실패", "id", id, "err", err)
+		}
+		return
+	}
+
+	s.notify(sch)
+	s.events.publish(schedulepb.EventType_EVENT_TYPE_FIRED, sch)
+	if err := s.awaitAck(sch, sch.NotifyCount+1); err != nil {
+		slog.Error("알림 상태 저장 실패", "id", id, "err", err)
+	}
+}
+
+// finish ends the ack cycle of a fired reminder. One-off reminders are
+// deleted; recurring ones go back to waiting for their next occurrence.
+func (s *ScheduleServer) finish(sch *schedulepb.ScheduleRequest) error {
+	if sch.Rrule == "" {
+		return s.remove(sch.Id)
+	}
+	sch.State = ""
+	sch.AckDue = ""
+	sch.NotifyCount = 0
+	sch.AckedBy = nil
+	return s.store.Update(sch)
+}
+
+// restoreAck re-arms the ack timer of a reminder loaded at startup.
+func (s *ScheduleServer) restoreAck(sch *schedulepb.ScheduleRequest) {
+	due, err := time.Parse(time.RFC3339, sch.AckDue)
+	if err != nil {
+		due = time.Now()
+	}
+	s.sched.Add(ackKey(sch.Id), due)
+}
+
+// dispatch routes a scheduler entry to the reminder, one of its lead-time
+// alerts or its ack timer.
+func (s *ScheduleServer) dispatch(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := strings.CutSuffix(key, ackSuffix); ok {
+		s.renotify(id)
+		return
+	}
+	if id, offset, ok := strings.Cut(key, alertSep); ok {
+		s.alert(id, offset)
+		return
+	}
+	s.fire(key)
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/notify"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+)
+
+// alertSep separates the schedule ID from the offset in scheduler entries
+// of lead-time alerts.
+const alertSep = "@"
+
+func alertKey(id, offset string) string {
+	return id + alertSep + offset
+}
+
+// normalizeAlerts validates alert offsets and rewrites them in canonical
+// form. Alerts after Datetime are not supported, since by then a recurring
+// schedule has already moved on to its next occurrence.
+func normalizeAlerts(alerts []string) ([]string, error) {
+	var out []string
+	for _, a := range alerts {
+		d, err := watcher.ParseOffset(a)
+		if err != nil {
+			return nil, err
+		}
+		if d > 0 {
+			return nil, fmt.Errorf("alert %q is after the schedule time", a)
+		}
+		if off := watcher.FormatOffset(d); !slices.Contains(out, off) {
+			out = append(out, off)
+		}
+	}
+	return out, nil
+}
+
+// notifiesAtEvent reports whether the schedule has an alert at Datetime
+// itself. Schedules without alerts always do.
+func notifiesAtEvent(sch *schedulepb.ScheduleRequest) bool {
+	return len(sch.Alerts) == 0 || slices.Contains(sch.Alerts, "0")
+}
+
+// leadAlerts calls fn with every alert before Datetime that has not fired
+// for the current occurrence.
+func leadAlerts(sch *schedulepb.ScheduleRequest, t time.Time, fn func(offset string, at time.Time)) {
+	for _, a := range sch.Alerts {
+		d, err := watcher.ParseOffset(a)
+		if err != nil || d == 0 || slices.Contains(sch.FiredAlerts, a) {
+			continue
+		}
+		fn(a, t.Add(d))
+	}
+}
+
+// nextAlert returns when the schedule will next notify.
+func nextAlert(sch *schedulepb.ScheduleRequest, now time.Time) (time.Time, bool) {
+	t, err := watcher.ParseDatetime(sch.Datetime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var next time.Time
+	leadAlerts(sch, t, func(_ string, at time.Time) {
+		if at.After(now) && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	})
+	if next.IsZero() && notifiesAtEvent(sch) && t.After(now) {
+		next = t
+	}
+	return next, !next.IsZero()
+}
+
+// disarm removes the schedule's Datetime entry and its lead-time alerts
+// from the scheduler.
+func (s *ScheduleServer) disarm(sch *schedulepb.ScheduleRequest) {
+	s.sched.Remove(sch.Id)
+	for _, a := range sch.Alerts {
+		s.sched.Remove(alertKey(sch.Id, a))
+	}
+}
+
+// restoreAlerts handles lead-time alerts that came due while the server was
+// down, following the same policy as missed reminders. Alerts that are
+// skipped are recorded as fired so they never go off later.
+func (s *ScheduleServer) restoreAlerts(sch *schedulepb.ScheduleRequest, t time.Time, policy watcher.MissedPolicy) {
+	now := time.Now()
+	skipped := false
+	leadAlerts(sch, t, func(offset string, at time.Time) {
+		if at.After(now) {
+			return
+		}
+		if policy == watcher.MissedFire {
+			s.sched.Add(alertKey(sch.Id, offset), now)
+			return
+		}
+		sch.FiredAlerts = append(sch.FiredAlerts, offset)
+		skipped = true
+	})
+	if skipped {
+		if err := s.store.Update(sch); err != nil {
+			slog.Error("알림 상태 저장 실패", "id", sch.Id, "err", err)
+		}
+	}
+}
+
+// alert sends a lead-time alert and records it so it does not repeat.
+func (s *ScheduleServer) alert(id, offset string) {
+	sch, err := s.store.Get(id)
+	if err != nil || !slices.Contains(sch.Alerts, offset) || slices.Contains(sch.FiredAlerts, offset) {
+		return
+	}
+
+	sch.FiredAlerts = append(sch.FiredAlerts, offset)
+	if err := s.store.Update(sch); err != nil {
+		slog.Error("알림 상태 저장 실패", "id", id, "err", err)
+		return
+	}
+
+	d, _ := watcher.ParseOffset(offset)
+	err = s.notifier.Notify(notify.Notification{
+		ID:    sch.Id,
+		Title: fmt.Sprintf("%s (%s 전)", sch.Title, formatLead(-d)),
+		Memo:  sch.Memo,
+		URL:   sch.Url,
+		Time:  time.Now(),
+		To:    sch.Emails,
+	})
+	if err != nil {
+		slog.Error("알림 전송 실패", "err", err)
+	}
+	s.events.publishAlert(sch, offset)
+}
+
+// formatLead renders a lead time in Korean, e.g. "1일 2시간 30분".
+func formatLead(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+
+	out := ""
+	for _, part := range []struct {
+		n    time.Duration
+		unit string
+	}{{days, "일"}, {hours, "시간"}, {minutes, "분"}} {
+		if part.n > 0 {
+			if out != "" {
+				out += " "
+			}
+			out += fmt.Sprintf("%d%s", part.n, part.unit)
+		}
+	}
+	if out == "" {
+		return "1분 미만"
+	}
+	return out
+}
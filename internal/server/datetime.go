@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"github.com/je0ng3/remindme-cli/internal/zone"
+)
+
+// normalizeTime rewrites Datetime as an RFC 3339 instant and fills in Tz.
+// Datetime may already be RFC 3339, or a wall-clock time in Layout that is
+// read in Tz. A wall time skipped by a DST transition is rejected; one that
+// occurs twice resolves to the earlier instant and a note is returned for
+// the response message.
+func normalizeTime(sch *schedulepb.ScheduleRequest) (note string, err error) {
+	loc, err := zone.Load(sch.Tz)
+	if err != nil {
+		return "", err
+	}
+	if sch.Tz == "" {
+		sch.Tz = zone.LocalName()
+	}
+
+	if t, err := time.Parse(time.RFC3339, sch.Datetime); err == nil {
+		sch.Datetime = t.In(loc).Format(time.RFC3339)
+		return "", nil
+	}
+
+	t, ambiguous, err := zone.Resolve(watcher.Layout, sch.Datetime, loc)
+	if _, ok := err.(*zone.NonexistentError); ok {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("%q is not RFC 3339 or %s", sch.Datetime, "YYYY-MM-DD HH:MM")
+	}
+	sch.Datetime = t.Format(time.RFC3339)
+	if ambiguous {
+		note = fmt.Sprintf(" %s occurs twice in %s; using the earlier %s.",
+			t.Format(watcher.Layout), loc, t.Format(time.RFC3339))
+	}
+	return note, nil
+}
+
+// location returns the zone a schedule was written in, falling back to
+// the local zone for schedules stored without one.
+func location(sch *schedulepb.ScheduleRequest) *time.Location {
+	loc, err := zone.Load(sch.Tz)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// eventBuffer is how many events a slow subscriber may fall behind before
+// events are dropped for it.
+const eventBuffer = 64
+
+// missedRetention is how long the missed events found at startup are
+// replayed to new subscribers. Restore runs before the server accepts
+// connections, so without them no watcher would ever see these events.
+const missedRetention = time.Minute
+
+// hub fans schedule events out to every WatchEvents stream.
+type hub struct {
+	mu       sync.Mutex
+	subs     map[chan *schedulepb.ScheduleEvent]struct{}
+	retained []*schedulepb.ScheduleEvent
+	until    time.Time
+}
+
+func (h *hub) subscribe() (<-chan *schedulepb.ScheduleEvent, func()) {
+	ch := make(chan *schedulepb.ScheduleEvent, eventBuffer)
+
+	h.mu.Lock()
+	if h.subs == nil {
+		h.subs = make(map[chan *schedulepb.ScheduleEvent]struct{})
+	}
+	h.subs[ch] = struct{}{}
+	if time.Now().Before(h.until) {
+		for _, ev := range h.retained {
+			ch <- ev
+		}
+	} else {
+		h.retained = nil
+	}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs, ch)
+		h.mu.Unlock()
+	}
+}
+
+func (h *hub) publish(typ schedulepb.EventType, sch *schedulepb.ScheduleRequest) {
+	h.send(&schedulepb.ScheduleEvent{
+		Type:     typ,
+		Schedule: proto.Clone(sch).(*schedulepb.ScheduleRequest),
+		Time:     time.Now().Format(time.RFC3339),
+	})
+}
+
+// publishMissed reports a reminder that passed while the server was down
+// and keeps the event for subscribers that connect within missedRetention.
+func (h *hub) publishMissed(sch *schedulepb.ScheduleRequest) {
+	ev := &schedulepb.ScheduleEvent{
+		Type:     schedulepb.EventType_EVENT_TYPE_MISSED,
+		Schedule: proto.Clone(sch).(*schedulepb.ScheduleRequest),
+		Time:     time.Now().Format(time.RFC3339),
+	}
+	h.send(ev)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// The oldest events go first; the rest must fit in a new subscriber's
+	// buffer.
+	if len(h.retained) == eventBuffer {
+		h.retained = h.retained[1:]
+	}
+	h.retained = append(h.retained, ev)
+	h.until = time.Now().Add(missedRetention)
+}
+
+// publishAlert reports a lead-time alert as a fired event.
+func (h *hub) publishAlert(sch *schedulepb.ScheduleRequest, offset string) {
+	h.send(&schedulepb.ScheduleEvent{
+		Type:     schedulepb.EventType_EVENT_TYPE_FIRED,
+		Schedule: proto.Clone(sch).(*schedulepb.ScheduleRequest),
+		Time:     time.Now().Format(time.RFC3339),
+		Alert:    offset,
+	})
+}
+
+// publishAck reports who acknowledged a fired reminder; user is empty
+// without authentication.
+func (h *hub) publishAck(sch *schedulepb.ScheduleRequest, user string) {
+	h.send(&schedulepb.ScheduleEvent{
+		Type:     schedulepb.EventType_EVENT_TYPE_ACKNOWLEDGED,
+		Schedule: proto.Clone(sch).(*schedulepb.ScheduleRequest),
+		Time:     time.Now().Format(time.RFC3339),
+		User:     user,
+	})
+}
+
+func (h *hub) send(ev *schedulepb.ScheduleEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- ev:
+		default:
+			slog.Warn("이벤트 구독자가 느려 이벤트를 버림", "type", ev.Type, "id", ev.Schedule.Id)
+		}
+	}
+}
+
+func (s *ScheduleServer) WatchEvents(req *schedulepb.WatchRequest, stream schedulepb.Scheduler_WatchEventsServer) error {
+	want := map[schedulepb.EventType]bool{}
+	for _, t := range req.Types {
+		want[t] = true
+	}
+
+	events, cancel := s.events.subscribe()
+	defer cancel()
+
+	// Send headers right away so the client knows the subscription is live.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case ev := <-events:
+			if len(want) > 0 && !want[ev.Type] || !s.visible(stream.Context(), ev.Schedule) {
+				continue
+			}
+			// Events are shared by every subscriber, so members are
+			// filled in on a copy.
+			if len(ev.Schedule.Assignees) > 0 {
+				ev = proto.Clone(ev).(*schedulepb.ScheduleEvent)
+				ev.Schedule.Members = s.members(ev.Schedule)
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -2,186 +2,477 @@ package server
 
 import (
 	"context"
-	"encoding/csv"
 	"errors"
-	"os"
+	"log/slog"
+	"slices"
 	"sync"
-	"text/tabwriter"
+	"time"
 
 	"github.com/google/uuid"
 	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/notify"
+	"github.com/je0ng3/remindme-cli/internal/recur"
+	"github.com/je0ng3/remindme-cli/internal/store"
 	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
-
 type ScheduleServer struct {
 	schedulepb.UnimplementedSchedulerServer
-	mu			sync.Mutex
-	csvFile		string
+	mu       sync.Mutex
+	store    store.Store
+	sched    *watcher.Scheduler
+	notifier notify.Notifier
+	events   hub
+	groups   map[string][]string
+	admin    string
+
+	renotifyInterval time.Duration
+	renotifyLimit    int
+}
+
+// Option configures a ScheduleServer.
+type Option func(*ScheduleServer)
+
+// WithNotifier sets the backend used to deliver fired reminders.
+func WithNotifier(n notify.Notifier) Option {
+	return func(s *ScheduleServer) {
+		s.notifier = n
+	}
 }
 
+// NewSchedulerServer serves schedules kept in a CSV file at csvPath.
+func NewSchedulerServer(csvPath string, opts ...Option) *ScheduleServer {
+	return NewServer(store.NewCSV(csvPath), opts...)
+}
 
-func NewSchedulerServer(csvPath string) *ScheduleServer {
-	return &ScheduleServer{
-		csvFile: csvPath,
+func NewServer(st store.Store, opts ...Option) *ScheduleServer {
+	s := &ScheduleServer{
+		store:            st,
+		renotifyInterval: 5 * time.Minute,
+		renotifyLimit:    3,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.notifier == nil {
+		s.notifier, _ = notify.New(notify.Default(), nil)
 	}
+	s.sched = watcher.NewScheduler(s.dispatch)
+	return s
 }
 
 func (s *ScheduleServer) AddSchedule(ctx context.Context, req *schedulepb.ScheduleRequest) (*schedulepb.ScheduleResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-
-	if req.Title == "" {
-		return nil, errors.New("title is required")
+	// The reminder state is the server's to keep.
+	req.State, req.AckDue, req.NotifyCount = "", "", 0
+	req.AckedBy, req.Members = nil, nil
+	req.FiredAlerts = nil
+	req.NextAlert = ""
+	note, err := s.validate(req, true)
+	if err != nil {
+		return nil, err
 	}
 	id := uuid.New().String()
 	req.Id = id
+	req.Owner, _ = auth.UserFrom(ctx)
 
-	file, err := os.OpenFile(s.csvFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
+	if err := s.store.Add(req); err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	s.arm(req)
+	s.events.publish(schedulepb.EventType_EVENT_TYPE_ADDED, req)
+	return &schedulepb.ScheduleResponse{Message: "Schedule added." + note}, nil
+}
 
-	err = writer.Write([]string{req.Id, req.Title, req.Datetime, req.Url, req.Memo})
+func (s *ScheduleServer) ListSchedules(ctx context.Context, req *schedulepb.ListSchedulesRequest) (*schedulepb.ScheduleList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, err := parseListQuery(req)
 	if err != nil {
 		return nil, err
 	}
-	go watcher.Watch(req, s)
-	return &schedulepb.ScheduleResponse{Message: "Schedule added."}, nil
-}
+	// Without authentication or --team every schedule is visible, so a
+	// plain query can leave the paging to the store.
+	_, authed := auth.UserFrom(ctx)
+	pushed := q.plain() && !authed && !req.Team
+	list, err := s.store.Query(q.storeQuery(pushed))
+	if err != nil {
+		return nil, err
+	}
+	var page []*schedulepb.ScheduleRequest
+	var next string
+	if pushed {
+		page, next = q.paged(list)
+	} else {
+		page, next = q.apply(s.filter(ctx, req, list))
+	}
 
+	now := time.Now()
+	for _, sch := range page {
+		if t, ok := nextAlert(sch, now); ok {
+			sch.NextAlert = t.Format(time.RFC3339)
+		}
+		sch.Members = s.members(sch)
+	}
+	return &schedulepb.ScheduleList{Schedules: page, NextPageToken: next}, nil
+}
 
-func (s *ScheduleServer) ListSchedules(ctx context.Context, _ *schedulepb.Empty) (*schedulepb.ScheduleList, error) {
+func (s *ScheduleServer) DeleteSchedule(ctx context.Context, req *schedulepb.ScheduleIdx) (*schedulepb.ScheduleResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-
-	file, err := os.Open(s.csvFile)
+	list, err := s.store.List()
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	// Indexes count the caller's schedules, as ListSchedules shows them.
+	list = s.scope(ctx, list)
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, err
+	idx := int(req.Idx) - 1
+	if idx < 0 || idx >= len(list) {
+		return &schedulepb.ScheduleResponse{Message: "Invalid index"}, nil
 	}
-
-	var list []*schedulepb.ScheduleRequest
-	for _, r := range records {
-		list = append(list, &schedulepb.ScheduleRequest{
-			Id:			r[0],
-			Title: 		r[1],
-			Datetime: 	r[2],
-			Url: 		r[3],
-			Memo: 		r[4],
-		})
+	if !s.owns(ctx, list[idx]) {
+		return nil, errNotOwner(list[idx])
 	}
+	if err := s.remove(list[idx].Id); err != nil {
+		return nil, err
+	}
+	s.events.publish(schedulepb.EventType_EVENT_TYPE_DELETED, list[idx])
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	w.Flush()
-
-	return &schedulepb.ScheduleList{Schedules: list}, nil
+	return &schedulepb.ScheduleResponse{Message: "Schedule deleted."}, nil
 }
 
-func (s *ScheduleServer) DeleteSchedule(ctx context.Context, req *schedulepb.ScheduleIdx) (*schedulepb.ScheduleResponse, error) {
+func (s *ScheduleServer) UpdateSchedule(ctx context.Context, req *schedulepb.UpdateScheduleRequest) (*schedulepb.ScheduleResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-
-	file, err := os.Open(s.csvFile)
+	cur, err := s.store.Get(req.Id)
+	if errors.Is(err, store.ErrNotFound) || (err == nil && !s.visible(ctx, cur)) {
+		return nil, status.Errorf(codes.NotFound, "schedule %s not found", req.Id)
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	if !s.owns(ctx, cur) {
+		return nil, errNotOwner(cur)
+	}
+	old := proto.Clone(cur).(*schedulepb.ScheduleRequest)
+	patch := req.Schedule
+	if patch == nil {
+		patch = &schedulepb.ScheduleRequest{}
+	}
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	mask := req.UpdateMask
+	if len(mask) == 0 {
+		for field, value := range map[string]string{
+			"title":    patch.Title,
+			"datetime": patch.Datetime,
+			"url":      patch.Url,
+			"memo":     patch.Memo,
+			"rrule":    patch.Rrule,
+			"tz":       patch.Tz,
+		} {
+			if value != "" {
+				mask = append(mask, field)
+			}
+		}
+		if len(patch.Alerts) > 0 {
+			mask = append(mask, "alerts")
+		}
+		if len(patch.Tags) > 0 {
+			mask = append(mask, "tags")
+		}
+		if len(patch.Assignees) > 0 {
+			mask = append(mask, "assignees")
+		}
+		if len(patch.Emails) > 0 {
+			mask = append(mask, "emails")
+		}
+	}
+	for _, field := range mask {
+		switch field {
+		case "title":
+			cur.Title = patch.Title
+		case "datetime":
+			cur.Datetime = patch.Datetime
+		case "url":
+			cur.Url = patch.Url
+		case "memo":
+			cur.Memo = patch.Memo
+		case "rrule":
+			cur.Rrule = patch.Rrule
+		case "alerts":
+			cur.Alerts = patch.Alerts
+		case "tz":
+			cur.Tz = patch.Tz
+		case "tags":
+			cur.Tags = patch.Tags
+		case "assignees":
+			cur.Assignees = patch.Assignees
+		case "emails":
+			cur.Emails = patch.Emails
+		case "state", "ack_due", "notify_count", "fired_alerts", "next_alert", "acked_by", "members", "owner":
+			var v violations
+			v.add("update_mask", "%s is kept by the server", field)
+			return nil, v.err()
+		default:
+			var v violations
+			v.add("update_mask", "unknown field %q", field)
+			return nil, v.err()
+		}
+	}
+
+	rearmed := false
+	for _, field := range mask {
+		rearmed = rearmed || field == "datetime"
+	}
+	if cur.Tz != old.Tz && !rearmed {
+		// Moving to another zone keeps the wall-clock time.
+		if t, err := watcher.ParseDatetime(cur.Datetime); err == nil {
+			cur.Datetime = t.In(location(old)).Format(watcher.Layout)
+		}
+		rearmed = true
+	}
+	note, err := s.validate(cur, rearmed)
 	if err != nil {
 		return nil, err
 	}
+	if rearmed && cur.State != "" {
+		// A new time starts the reminder over.
+		cur.State, cur.AckDue, cur.NotifyCount, cur.AckedBy = "", "", 0, nil
+		s.sched.Remove(ackKey(cur.Id))
+	}
+	if rearmed {
+		cur.FiredAlerts = nil
+	}
+	var fired []string
+	for _, a := range cur.FiredAlerts {
+		if slices.Contains(cur.Alerts, a) {
+			fired = append(fired, a)
+		}
+	}
+	cur.FiredAlerts = fired
 
-	idx := int(req.Idx) - 1
-	if idx < 0 || idx >= len(records) {
-		return &schedulepb.ScheduleResponse{Message: "Invalid index"}, nil
+	if err := s.store.Update(cur); err != nil {
+		return nil, err
 	}
-	records = append(records[:idx], records[idx+1:]...)
+	s.disarm(old)
+	if cur.State == "" || cur.Rrule != "" {
+		s.arm(cur)
+	}
+	s.events.publish(schedulepb.EventType_EVENT_TYPE_UPDATED, cur)
+	return &schedulepb.ScheduleResponse{Message: "Schedule updated." + note}, nil
+}
+
+func (s *ScheduleServer) GetSchedule(ctx context.Context, req *schedulepb.ScheduleId) (*schedulepb.ScheduleRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resolve(ctx, req.Id)
+}
 
-	updatedFile, err := os.Create(s.csvFile)
+func (s *ScheduleServer) DeleteScheduleById(ctx context.Context, req *schedulepb.ScheduleId) (*schedulepb.ScheduleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sch, err := s.resolve(ctx, req.Id)
 	if err != nil {
 		return nil, err
 	}
-	defer updatedFile.Close()
+	if !s.owns(ctx, sch) {
+		return nil, errNotOwner(sch)
+	}
+	if err := s.remove(sch.Id); err != nil {
+		return nil, err
+	}
+	s.events.publish(schedulepb.EventType_EVENT_TYPE_DELETED, sch)
+	return &schedulepb.ScheduleResponse{Message: "Schedule deleted."}, nil
+}
+
+// minPrefix is the shortest ID prefix resolve accepts.
+const minPrefix = 4
 
-	writer := csv.NewWriter(updatedFile)
-	defer writer.Flush()
-	
-	err = writer.WriteAll(records)
+// resolve finds the caller's schedule whose ID is id or starts with id. A
+// prefix that matches more than one schedule is rejected.
+func (s *ScheduleServer) resolve(ctx context.Context, id string) (*schedulepb.ScheduleRequest, error) {
+	if len(id) < minPrefix {
+		return nil, status.Errorf(codes.InvalidArgument, "id prefix must be at least %d characters", minPrefix)
+	}
+
+	matches, err := s.store.FindPrefix(id)
 	if err != nil {
 		return nil, err
 	}
+	matches = s.scope(ctx, matches)
+	for _, sch := range matches {
+		if sch.Id == id {
+			return sch, nil
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, status.Errorf(codes.NotFound, "schedule %s not found", id)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "id prefix %s is ambiguous (%d matches)", id, len(matches))
+}
 
-	return &schedulepb.ScheduleResponse{Message: "Schedule deleted."}, nil
+// Delete removes a schedule and its timers.
+func (s *ScheduleServer) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remove(id)
 }
 
+func (s *ScheduleServer) remove(id string) error {
+	if sch, err := s.store.Get(id); err == nil {
+		s.disarm(sch)
+	}
+	s.sched.Remove(ackKey(id))
+	return s.store.Delete(id)
+}
 
-func (s *ScheduleServer) Delete(id string) error {
+func (s *ScheduleServer) Exists(id string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	_, err := s.store.Get(id)
+	return err == nil
+}
 
-	file, err := os.Open(s.csvFile)
+// Restore arms every stored schedule. It is meant to be called once at
+// startup, before the server starts accepting requests.
+func (s *ScheduleServer) Restore(policy watcher.MissedPolicy) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list, err := s.store.List()
 	if err != nil {
-		return err
+		return 0, err
 	}
-	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		return err
+	for _, req := range list {
+		if req.State != "" {
+			s.restoreAck(req)
+			if req.Rrule == "" {
+				continue
+			}
+		}
+		t, err := watcher.ParseDatetime(req.Datetime)
+		if err != nil {
+			slog.Warn("날짜 포맷 불일치", "id", req.Id, "err", err)
+			continue
+		}
+		if time.Until(t) <= 0 {
+			s.events.publishMissed(req)
+			if policy == watcher.MissedSkip {
+				slog.Info("놓친 알림 건너뜀", "title", req.Title, "datetime", req.Datetime)
+				if !s.advance(req) {
+					s.remove(req.Id)
+				}
+				continue
+			}
+			slog.Info("놓친 알림 전송", "title", req.Title, "datetime", req.Datetime)
+			s.sched.Add(req.Id, t)
+			continue
+		}
+		s.restoreAlerts(req, t, policy)
+		s.arm(req)
 	}
+	return len(list), nil
+}
 
-	var updatedRecords [][]string
-	for _, record := range records {
-		if record[0] != id {
-			updatedRecords = append(updatedRecords, record)
+// arm hands a stored schedule and its pending lead-time alerts to the
+// scheduler. Times that are already due are left alone.
+func (s *ScheduleServer) arm(req *schedulepb.ScheduleRequest) {
+	t, err := watcher.ParseDatetime(req.Datetime)
+	if err != nil {
+		slog.Warn("날짜 포맷 불일치", "id", req.Id, "err", err)
+		return
+	}
+	now := time.Now()
+	leadAlerts(req, t, func(offset string, at time.Time) {
+		if at.After(now) {
+			s.sched.Add(alertKey(req.Id, offset), at)
 		}
+	})
+	if !t.After(now) {
+		return
 	}
+	s.sched.Add(req.Id, t)
+}
 
-	updatedFile, err := os.Create(s.csvFile)
+func (s *ScheduleServer) fire(id string) {
+	req, err := s.store.Get(id)
 	if err != nil {
-		return err
+		return
+	}
+	atEvent := notifiesAtEvent(req)
+	if atEvent {
+		s.notify(req)
+		s.events.publish(schedulepb.EventType_EVENT_TYPE_FIRED, req)
 	}
-	defer updatedFile.Close()
 
-	writer := csv.NewWriter(updatedFile)
-	defer writer.Flush()
-	return writer.WriteAll(updatedRecords)
+	if !s.advance(req) {
+		// The series is over, so from here on it is handled as a one-off.
+		req.Rrule = ""
+	}
+	// Every member acknowledges each occurrence afresh.
+	req.AckedBy = nil
+	if s.renotifyLimit == 0 || !atEvent {
+		if req.Rrule == "" {
+			s.remove(id)
+		}
+		return
+	}
+	if err := s.awaitAck(req, 1); err != nil {
+		slog.Error("알림 상태 저장 실패", "id", id, "err", err)
+	}
 }
 
-func (s *ScheduleServer) Exists(id string) bool {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *ScheduleServer) notify(req *schedulepb.ScheduleRequest) {
+	slog.Debug("알림 전송", "id", req.Id, "title", req.Title)
+	err := s.notifier.Notify(notify.Notification{
+		ID:    req.Id,
+		Title: req.Title,
+		Memo:  req.Memo,
+		URL:   req.Url,
+		Time:  time.Now(),
+		To:    req.Emails,
+	})
+	if err != nil {
+		slog.Error("알림 전송 실패", "id", req.Id, "err", err)
+	}
+}
 
-	file, err := os.Open(s.csvFile)
+// advance moves a recurring schedule to its next occurrence after now and
+// re-arms it. It reports false for one-off schedules and finished series.
+func (s *ScheduleServer) advance(req *schedulepb.ScheduleRequest) bool {
+	if req.Rrule == "" {
+		return false
+	}
+	rule, err := recur.Parse(req.Rrule)
 	if err != nil {
+		slog.Error("반복 규칙 오류", "id", req.Id, "err", err)
 		return false
 	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	t, err := watcher.ParseDatetime(req.Datetime)
 	if err != nil {
 		return false
 	}
+	next, rest, ok := rule.Advance(t.In(location(req)), time.Now())
+	if !ok {
+		return false
+	}
 
-	for _, record := range records {
-		if record[0] == id {
-			return true
-		}
+	req.Datetime = next.Format(time.RFC3339)
+	req.Rrule = rest.String()
+	req.FiredAlerts = nil
+	if err := s.store.Update(req); err != nil {
+		slog.Error("다음 반복 저장 실패", "id", req.Id, "err", err)
+		return false
 	}
-	return false
-}
\ No newline at end of file
+	s.arm(req)
+	return true
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"encoding/base64"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/store"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+)
+
+// maxPageSize caps page_size so one request cannot pull the whole store.
+const maxPageSize = 1000
+
+// listQuery is a validated ListSchedulesRequest.
+type listQuery struct {
+	from, to time.Time
+	query    string
+	regex    *regexp.Regexp
+	tags     []string
+	sortKey  string
+	desc     bool
+	offset   int
+	size     int
+}
+
+func parseListQuery(req *schedulepb.ListSchedulesRequest) (*listQuery, error) {
+	var v violations
+	q := &listQuery{query: strings.ToLower(req.Query), tags: req.Tags}
+
+	for _, b := range []struct {
+		field string
+		value string
+		t     *time.Time
+	}{{"from", req.From, &q.from}, {"to", req.To, &q.to}} {
+		if b.value == "" {
+			continue
+		}
+		t, err := watcher.ParseDatetime(b.value)
+		if err != nil {
+			v.add(b.field, "%q is not an RFC 3339 time", b.value)
+			continue
+		}
+		*b.t = t
+	}
+	if !q.from.IsZero() && !q.to.IsZero() && !q.to.After(q.from) {
+		v.add("to", "must be after from")
+	}
+
+	if req.Regex != "" {
+		re, err := regexp.Compile(req.Regex)
+		if err != nil {
+			v.add("regex", "%v", err)
+		}
+		q.regex = re
+	}
+
+	q.sortKey, q.desc = strings.CutPrefix(req.Sort, "-")
+	switch q.sortKey {
+	case "", "added", "datetime", "title":
+	default:
+		v.add("sort", "unknown key %q; want added, datetime or title", q.sortKey)
+	}
+
+	switch {
+	case req.PageSize < 0:
+		v.add("page_size", "must not be negative")
+	case req.PageSize > maxPageSize:
+		q.size = maxPageSize
+	default:
+		q.size = int(req.PageSize)
+	}
+	if req.PageToken != "" {
+		offset, err := decodePageToken(req.PageToken)
+		if err != nil {
+			v.add("page_token", "invalid page token")
+		}
+		q.offset = offset
+	}
+
+	return q, v.err()
+}
+
+// plain reports whether q keeps the store's order and filters on nothing
+// but the due time, so the store can page as well.
+func (q *listQuery) plain() bool {
+	return q.query == "" && q.regex == nil && len(q.tags) == 0 && (q.sortKey == "" || q.sortKey == "added") && !q.desc
+}
+
+// storeQuery is the part of q the store evaluates: the due-time range, and
+// the page too when paged is set. One schedule more than the page is asked
+// for to learn whether another page follows.
+func (q *listQuery) storeQuery(paged bool) store.Query {
+	sq := store.Query{From: q.from, To: q.to}
+	if paged {
+		sq.Offset = q.offset
+		if q.size > 0 {
+			sq.Limit = q.size + 1
+		}
+	}
+	return sq
+}
+
+// paged cuts a page the store already selected with storeQuery(true).
+func (q *listQuery) paged(list []*schedulepb.ScheduleRequest) ([]*schedulepb.ScheduleRequest, string) {
+	if q.size == 0 || len(list) <= q.size {
+		return list, ""
+	}
+	return list[:q.size], encodePageToken(q.offset + q.size)
+}
+
+// apply filters, sorts and pages list, returning the page and the token
+// for the next one.
+func (q *listQuery) apply(list []*schedulepb.ScheduleRequest) ([]*schedulepb.ScheduleRequest, string) {
+	var out []*schedulepb.ScheduleRequest
+	for _, sch := range list {
+		if q.match(sch) {
+			out = append(out, sch)
+		}
+	}
+	q.sort(out)
+
+	if q.offset >= len(out) {
+		return nil, ""
+	}
+	out = out[q.offset:]
+	if q.size == 0 || len(out) <= q.size {
+		return out, ""
+	}
+	return out[:q.size], encodePageToken(q.offset + q.size)
+}
+
+// match applies the filters the store does not; the due-time range is in
+// storeQuery.
+func (q *listQuery) match(sch *schedulepb.ScheduleRequest) bool {
+	if q.query != "" && !strings.Contains(strings.ToLower(sch.Title), q.query) &&
+		!strings.Contains(strings.ToLower(sch.Memo), q.query) {
+		return false
+	}
+	if q.regex != nil && !q.regex.MatchString(sch.Title) && !q.regex.MatchString(sch.Memo) {
+		return false
+	}
+	for _, tag := range q.tags {
+		if !slices.ContainsFunc(sch.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// sort orders list in place. By datetime, schedules whose datetime cannot
+// be parsed count as the latest.
+func (q *listQuery) sort(list []*schedulepb.ScheduleRequest) {
+	var cmp func(a, b *schedulepb.ScheduleRequest) int
+	switch q.sortKey {
+	case "datetime":
+		cmp = func(a, b *schedulepb.ScheduleRequest) int {
+			ta, errA := watcher.ParseDatetime(a.Datetime)
+			tb, errB := watcher.ParseDatetime(b.Datetime)
+			if errA != nil || errB != nil {
+				return boolCompare(errA != nil, errB != nil)
+			}
+			return ta.Compare(tb)
+		}
+	case "title":
+		cmp = func(a, b *schedulepb.ScheduleRequest) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+	default:
+		if q.desc {
+			slices.Reverse(list)
+		}
+		return
+	}
+	slices.SortStableFunc(list, func(a, b *schedulepb.ScheduleRequest) int {
+		if q.desc {
+			return cmp(b, a)
+		}
+		return cmp(a, b)
+	})
+}
+
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// Page tokens are opaque to clients; they carry the offset of the next
+// page.
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := strings.CutPrefix(string(b), "offset:")
+	if !ok {
+		return 0, strconv.ErrSyntax
+	}
+	offset, err := strconv.Atoi(n)
+	if err != nil || offset < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return offset, nil
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"slices"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithGroups sets the named groups schedules can be assigned to as
+// "@name", each a list of user names.
+func WithGroups(groups map[string][]string) Option {
+	return func(s *ScheduleServer) {
+		s.groups = groups
+	}
+}
+
+// WithAdmin names the user who owns the schedules added while
+// authentication was off, which have no owner.
+func WithAdmin(name string) Option {
+	return func(s *ScheduleServer) {
+		s.admin = name
+	}
+}
+
+// members expands the assignees of sch into user names, in order and
+// without repeats. Unknown groups expand to nobody.
+func (s *ScheduleServer) members(sch *schedulepb.ScheduleRequest) []string {
+	var out []string
+	add := func(name string) {
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	for _, a := range sch.Assignees {
+		if a[0] == '@' {
+			for _, name := range s.groups[a[1:]] {
+				add(name)
+			}
+			continue
+		}
+		add(a)
+	}
+	return out
+}
+
+// owns reports whether the caller may change or delete sch: its own
+// schedules, and for the admin those added before authentication was turned
+// on, which have no owner. Without authentication every schedule is the
+// caller's.
+func (s *ScheduleServer) owns(ctx context.Context, sch *schedulepb.ScheduleRequest) bool {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return true
+	}
+	if sch.Owner == "" {
+		return s.admin != "" && user == s.admin
+	}
+	return sch.Owner == user
+}
+
+func errNotOwner(sch *schedulepb.ScheduleRequest) error {
+	if sch.Owner == "" {
+		return status.Errorf(codes.PermissionDenied, "schedule %s has no owner; only the admin can change it", sch.Id)
+	}
+	return status.Errorf(codes.PermissionDenied, "schedule %s belongs to %s", sch.Id, sch.Owner)
+}
+
+// visible reports whether the caller may see and acknowledge sch: the
+// schedules it owns and the team schedules it is a member of.
+func (s *ScheduleServer) visible(ctx context.Context, sch *schedulepb.ScheduleRequest) bool {
+	if s.owns(ctx, sch) {
+		return true
+	}
+	user, _ := auth.UserFrom(ctx)
+	return slices.Contains(s.members(sch), user)
+}
+
+// scope keeps the schedules visible to the caller.
+func (s *ScheduleServer) scope(ctx context.Context, list []*schedulepb.ScheduleRequest) []*schedulepb.ScheduleRequest {
+	out := list[:0:0]
+	for _, sch := range list {
+		if s.visible(ctx, sch) {
+			out = append(out, sch)
+		}
+	}
+	return out
+}
+
+// filter keeps the schedules visible to the caller, narrowed to the
+// caller's own and assigned ones with req.Mine and to team schedules with
+// req.Team.
+func (s *ScheduleServer) filter(ctx context.Context, req *schedulepb.ListSchedulesRequest, list []*schedulepb.ScheduleRequest) []*schedulepb.ScheduleRequest {
+	user, authed := auth.UserFrom(ctx)
+	out := list[:0:0]
+	for _, sch := range s.scope(ctx, list) {
+		if req.Team && len(sch.Assignees) == 0 {
+			continue
+		}
+		if req.Mine && authed && sch.Owner != user && !slices.Contains(s.members(sch), user) {
+			continue
+		}
+		out = append(out, sch)
+	}
+	return out
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/recur"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Length limits, counted in characters.
+const (
+	maxTitleLen = 200
+	maxMemoLen  = 2000
+	maxURLLen   = 2048
+	maxTagLen   = 50
+)
+
+// violations collects the field problems of one request.
+type violations []*errdetails.BadRequest_FieldViolation
+
+func (v *violations) add(field, format string, args ...any) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// err returns an InvalidArgument status carrying every violation as
+// BadRequest details, or nil if there are none.
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(v))
+	for i, fv := range v {
+		msgs[i] = fv.Field + ": " + fv.Description
+	}
+	st := status.New(codes.InvalidArgument, "invalid schedule: "+strings.Join(msgs, "; "))
+	if ds, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v}); err == nil {
+		st = ds
+	}
+	return st.Err()
+}
+
+// validate checks every user-editable field of sch and normalizes Rrule,
+// Alerts, Tags, Assignees, Emails, Tz and Datetime in place. With
+// checkPast a Datetime that has already gone by is rejected too. The note
+// is passed on from normalizeTime.
+func (s *ScheduleServer) validate(sch *schedulepb.ScheduleRequest, checkPast bool) (note string, err error) {
+	var v violations
+
+	switch n := utf8.RuneCountInString(strings.TrimSpace(sch.Title)); {
+	case n == 0:
+		v.add("title", "required")
+	case n > maxTitleLen:
+		v.add("title", "longer than %d characters", maxTitleLen)
+	}
+
+	if sch.Datetime == "" {
+		v.add("datetime", "required")
+	} else if n, err := normalizeTime(sch); err != nil {
+		v.add("datetime", "%v", err)
+	} else {
+		note = n
+		t, _ := time.Parse(time.RFC3339, sch.Datetime)
+		if checkPast && !t.After(time.Now()) {
+			v.add("datetime", "%s is in the past", sch.Datetime)
+		}
+	}
+
+	if sch.Url != "" {
+		u, err := url.Parse(sch.Url)
+		switch {
+		case utf8.RuneCountInString(sch.Url) > maxURLLen:
+			v.add("url", "longer than %d characters", maxURLLen)
+		case err != nil:
+			v.add("url", "not a valid URL")
+		case u.Scheme != "http" && u.Scheme != "https":
+			v.add("url", "scheme must be http or https")
+		case u.Host == "":
+			v.add("url", "missing host")
+		}
+	}
+
+	if utf8.RuneCountInString(sch.Memo) > maxMemoLen {
+		v.add("memo", "longer than %d characters", maxMemoLen)
+	}
+
+	if sch.Rrule != "" {
+		if rule, err := recur.Parse(sch.Rrule); err != nil {
+			v.add("rrule", "%v", err)
+		} else {
+			sch.Rrule = rule.String()
+		}
+	}
+
+	if alerts, err := normalizeAlerts(sch.Alerts); err != nil {
+		v.add("alerts", "%v", err)
+	} else {
+		sch.Alerts = alerts
+	}
+
+	var tags []string
+	for _, tag := range sch.Tags {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case tag == "":
+			continue
+		case strings.ContainsAny(tag, ", \t\n"):
+			v.add("tags", "%q contains a comma or space", tag)
+		case utf8.RuneCountInString(tag) > maxTagLen:
+			v.add("tags", "%q is longer than %d characters", tag, maxTagLen)
+		case !slices.Contains(tags, tag):
+			tags = append(tags, tag)
+		}
+	}
+	sch.Tags = tags
+
+	var assignees []string
+	for _, a := range sch.Assignees {
+		a = strings.TrimSpace(a)
+		group, isGroup := strings.CutPrefix(a, "@")
+		switch {
+		case a == "":
+			continue
+		case !auth.ValidName(group):
+			v.add("assignees", "%q is not a user or @group name", a)
+		case isGroup && s.groups[group] == nil:
+			v.add("assignees", "unknown group %q", a)
+		case !slices.Contains(assignees, a):
+			assignees = append(assignees, a)
+		}
+	}
+	sch.Assignees = assignees
+
+	var emails []string
+	for _, e := range sch.Emails {
+		if strings.TrimSpace(e) == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(e)
+		switch {
+		case err != nil:
+			v.add("emails", "%q is not an email address", strings.TrimSpace(e))
+		case !slices.Contains(emails, addr.Address):
+			emails = append(emails, addr.Address)
+		}
+	}
+	sch.Emails = emails
+
+	return note, v.err()
+}
@@ -0,0 +1,352 @@
+package store
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+)
+
+// CSVStore keeps every schedule as one row of a CSV file. Each call reads
+// the whole file, and changes atomically replace it.
+type CSVStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewCSV(path string) *CSVStore {
+	return &CSVStore{path: path}
+}
+
+func (c *CSVStore) Add(sch *schedulepb.ScheduleRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rewrite(func(records [][]string) ([][]string, error) {
+		return append(records, toRecord(sch)), nil
+	})
+}
+
+func (c *CSVStore) List() ([]*schedulepb.ScheduleRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*schedulepb.ScheduleRequest
+	for _, r := range records {
+		list = append(list, fromRecord(r))
+	}
+	return list, nil
+}
+
+func (c *CSVStore) Query(q Query) ([]*schedulepb.ScheduleRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*schedulepb.ScheduleRequest
+	skip := q.Offset
+	for _, r := range records {
+		if q.Limit > 0 && len(list) == q.Limit {
+			break
+		}
+		sch := fromRecord(r)
+		if !q.match(sch) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		list = append(list, sch)
+	}
+	return list, nil
+}
+
+func (c *CSVStore) Get(id string) (*schedulepb.ScheduleRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if r[0] == id {
+			return fromRecord(r), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (c *CSVStore) FindPrefix(prefix string) ([]*schedulepb.ScheduleRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	var list []*schedulepb.ScheduleRequest
+	for _, r := range records {
+		if strings.HasPrefix(r[0], prefix) {
+			list = append(list, fromRecord(r))
+		}
+	}
+	return list, nil
+}
+
+func (c *CSVStore) Update(sch *schedulepb.ScheduleRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rewrite(func(records [][]string) ([][]string, error) {
+		found := false
+		for i, r := range records {
+			if r[0] == sch.Id {
+				records[i] = toRecord(sch)
+				found = true
+			}
+		}
+		if !found {
+			return nil, ErrNotFound
+		}
+		return records, nil
+	})
+}
+
+func (c *CSVStore) Delete(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rewrite(func(records [][]string) ([][]string, error) {
+		var updated [][]string
+		for _, r := range records {
+			if r[0] != id {
+				updated = append(updated, r)
+			}
+		}
+		if len(updated) == len(records) {
+			return nil, ErrNotFound
+		}
+		return updated, nil
+	})
+}
+
+func (c *CSVStore) Close() error {
+	return nil
+}
+
+// Check verifies every row of the file. Rows that cannot be parsed or have
+// the wrong number of columns are appended to <path>.quarantine and removed
+// from the store, so one bad row does not hide the rest. It returns the
+// number of quarantined rows.
+func (c *CSVStore) Check() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	good, bad, err := c.scanFile()
+	if err != nil || len(bad) == 0 {
+		return 0, err
+	}
+	if err := c.quarantine(bad); err != nil {
+		return 0, err
+	}
+	return len(bad), c.write(good)
+}
+
+// quarantine appends the raw rows to <path>.quarantine and syncs it.
+func (c *CSVStore) quarantine(rows [][]byte) error {
+	q, err := os.OpenFile(c.path+".quarantine", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if !bytes.HasSuffix(row, []byte("\n")) {
+			row = append(row, '\n')
+		}
+		if _, err := q.Write(row); err != nil {
+			q.Close()
+			return err
+		}
+	}
+	if err := q.Sync(); err != nil {
+		q.Close()
+		return err
+	}
+	return q.Close()
+}
+
+// read returns every usable row of the file. A missing file is an empty
+// store. Malformed rows are skipped here; Check and rewrite move them aside.
+func (c *CSVStore) read() ([][]string, error) {
+	good, _, err := c.scanFile()
+	return good, err
+}
+
+// scanFile splits the file into usable records and malformed raw rows.
+func (c *CSVStore) scanFile() (good [][]string, bad [][]byte, err error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	good, bad = scan(data)
+	return good, bad, nil
+}
+
+// rewrite replaces the file with the rows change makes of the current
+// ones. Rows that became malformed since Check ran are quarantined first,
+// so replacing the file never loses them.
+func (c *CSVStore) rewrite(change func([][]string) ([][]string, error)) error {
+	good, bad, err := c.scanFile()
+	if err != nil {
+		return err
+	}
+	records, err := change(good)
+	if err != nil {
+		return err
+	}
+	if len(bad) > 0 {
+		if err := c.quarantine(bad); err != nil {
+			return err
+		}
+	}
+	return c.write(records)
+}
+
+// write replaces the file with records. The rows go to a temporary file in
+// the same directory, which is synced and renamed over the original, so a
+// crash or a full disk leaves either the old or the new file, never a
+// truncated one.
+func (c *CSVStore) write(records [][]string) error {
+	dir := filepath.Dir(c.path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := csv.NewWriter(tmp)
+	if err := writer.WriteAll(records); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return err
+	}
+	return nil
+}
+
+// scan parses data row by row. It returns the valid records and the raw
+// bytes of every row that failed to parse or has a bad column count.
+func scan(data []byte) (good [][]string, bad [][]byte) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	for {
+		start := reader.InputOffset()
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		end := reader.InputOffset()
+		if err != nil || !valid(record) {
+			bad = append(bad, data[start:end])
+			if end == start {
+				break
+			}
+			continue
+		}
+		good = append(good, record)
+	}
+	return good, bad
+}
+
+// minColumns is the column count of rows written before recurrence was
+// added.
+const minColumns = 5
+
+func valid(r []string) bool {
+	return len(r) >= minColumns && len(r) <= columns && r[0] != ""
+}
+
+// columns is the number of fields in a record. Rows written by older
+// versions have fewer columns and are padded when read.
+const columns = 17
+
+func toRecord(sch *schedulepb.ScheduleRequest) []string {
+	count := ""
+	if sch.NotifyCount != 0 {
+		count = strconv.Itoa(int(sch.NotifyCount))
+	}
+	return []string{sch.Id, sch.Title, sch.Datetime, sch.Url, sch.Memo, sch.Rrule, sch.State, sch.AckDue, count,
+		strings.Join(sch.Alerts, ","), strings.Join(sch.FiredAlerts, ","), sch.Tz, strings.Join(sch.Tags, ","), sch.Owner,
+		strings.Join(sch.Assignees, ","), strings.Join(sch.AckedBy, ","), strings.Join(sch.Emails, ",")}
+}
+
+func fromRecord(r []string) *schedulepb.ScheduleRequest {
+	for len(r) < columns {
+		r = append(r, "")
+	}
+	count, _ := strconv.Atoi(r[8])
+	return &schedulepb.ScheduleRequest{
+		Id:          r[0],
+		Title:       r[1],
+		Datetime:    r[2],
+		Url:         r[3],
+		Memo:        r[4],
+		Rrule:       r[5],
+		State:       r[6],
+		AckDue:      r[7],
+		NotifyCount: int32(count),
+		Alerts:      splitList(r[9]),
+		FiredAlerts: splitList(r[10]),
+		Tz:          r[11],
+		Tags:        splitList(r[12]),
+		Owner:       r[13],
+		Assignees:   splitList(r[14]),
+		AckedBy:     splitList(r[15]),
+		Emails:      splitList(r[16]),
+	}
+}
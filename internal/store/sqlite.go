@@ -0,0 +1,256 @@
+package store
+
+import (
+	"database/sql"
+	"strings"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore keeps schedules in an embedded SQLite database file. The seq
+// column preserves insertion order for list indexes. The due column holds
+// datetime as Unix seconds, NULL when it cannot be parsed, and is indexed
+// so Query can select a time range without a full scan; datetime itself is
+// text with mixed offsets and does not sort by time.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS schedules (
+	seq      INTEGER PRIMARY KEY AUTOINCREMENT,
+	id       TEXT NOT NULL UNIQUE,
+	title    TEXT NOT NULL,
+	datetime TEXT NOT NULL,
+	url      TEXT NOT NULL DEFAULT '',
+	memo     TEXT NOT NULL DEFAULT '',
+	rrule    TEXT NOT NULL DEFAULT ''
+);
+`
+
+// migrations lists columns added after the first schema, so databases
+// created by older versions are upgraded in place.
+var migrations = []struct{ column, def string }{
+	{"state", "TEXT NOT NULL DEFAULT ''"},
+	{"ack_due", "TEXT NOT NULL DEFAULT ''"},
+	{"notify_count", "INTEGER NOT NULL DEFAULT 0"},
+	{"alerts", "TEXT NOT NULL DEFAULT ''"},
+	{"fired_alerts", "TEXT NOT NULL DEFAULT ''"},
+	{"tz", "TEXT NOT NULL DEFAULT ''"},
+	{"tags", "TEXT NOT NULL DEFAULT ''"},
+	{"owner", "TEXT NOT NULL DEFAULT ''"},
+	{"assignees", "TEXT NOT NULL DEFAULT ''"},
+	{"acked_by", "TEXT NOT NULL DEFAULT ''"},
+	{"emails", "TEXT NOT NULL DEFAULT ''"},
+	{"due", "INTEGER"},
+}
+
+func migrate(db *sql.DB) error {
+	rows, err := db.Query("SELECT name FROM pragma_table_info('schedules')")
+	if err != nil {
+		return err
+	}
+	have := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		have[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if have[m.column] {
+			continue
+		}
+		if _, err := db.Exec("ALTER TABLE schedules ADD COLUMN " + m.column + " " + m.def); err != nil {
+			return err
+		}
+	}
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS schedules_due ON schedules (due)"); err != nil {
+		return err
+	}
+	return fillDue(db)
+}
+
+// fillDue sets the due column of rows written before it existed.
+func fillDue(db *sql.DB) error {
+	rows, err := db.Query("SELECT id, datetime FROM schedules WHERE due IS NULL")
+	if err != nil {
+		return err
+	}
+	fill := map[string]int64{}
+	for rows.Next() {
+		var id, datetime string
+		if err := rows.Scan(&id, &datetime); err != nil {
+			rows.Close()
+			return err
+		}
+		if due, ok := dueOf(datetime).(int64); ok {
+			fill[id] = due
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for id, due := range fill {
+		if _, err := db.Exec("UPDATE schedules SET due = ? WHERE id = ?", due, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dueOf is the due column value for datetime: Unix seconds, or nil.
+func dueOf(datetime string) any {
+	t, err := watcher.ParseDatetime(datetime)
+	if err != nil {
+		return nil
+	}
+	return t.Unix()
+}
+
+func OpenSQLite(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+const columnList = "id, title, datetime, url, memo, rrule, state, ack_due, notify_count, alerts, fired_alerts, tz, tags, owner, assignees, acked_by, emails"
+
+func (s *SQLiteStore) Add(sch *schedulepb.ScheduleRequest) error {
+	_, err := s.db.Exec("INSERT INTO schedules ("+columnList+", due) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		sch.Id, sch.Title, sch.Datetime, sch.Url, sch.Memo, sch.Rrule, sch.State, sch.AckDue, sch.NotifyCount,
+		strings.Join(sch.Alerts, ","), strings.Join(sch.FiredAlerts, ","), sch.Tz, strings.Join(sch.Tags, ","), sch.Owner,
+		strings.Join(sch.Assignees, ","), strings.Join(sch.AckedBy, ","), strings.Join(sch.Emails, ","), dueOf(sch.Datetime))
+	return err
+}
+
+func (s *SQLiteStore) List() ([]*schedulepb.ScheduleRequest, error) {
+	return s.query("SELECT " + columnList + " FROM schedules ORDER BY seq")
+}
+
+func (s *SQLiteStore) Get(id string) (*schedulepb.ScheduleRequest, error) {
+	list, err := s.query("SELECT "+columnList+" FROM schedules WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (s *SQLiteStore) Query(q Query) ([]*schedulepb.ScheduleRequest, error) {
+	var where []string
+	var args []any
+	if !q.From.IsZero() {
+		where, args = append(where, "due >= ?"), append(args, q.From.Unix())
+	}
+	if !q.To.IsZero() {
+		where, args = append(where, "due < ?"), append(args, q.To.Unix())
+	}
+	stmt := "SELECT " + columnList + " FROM schedules"
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	stmt += " ORDER BY seq"
+	if q.Limit > 0 || q.Offset > 0 {
+		// A negative LIMIT is no limit.
+		limit := -1
+		if q.Limit > 0 {
+			limit = q.Limit
+		}
+		stmt += " LIMIT ? OFFSET ?"
+		args = append(args, limit, q.Offset)
+	}
+	return s.query(stmt, args...)
+}
+
+func (s *SQLiteStore) FindPrefix(prefix string) ([]*schedulepb.ScheduleRequest, error) {
+	// A range scan on the unique id index; LIKE would need escaping and
+	// is case-insensitive.
+	return s.query("SELECT "+columnList+" FROM schedules WHERE id >= ? AND id < ? ORDER BY seq",
+		prefix, prefix+"\U0010FFFF")
+}
+
+func (s *SQLiteStore) Update(sch *schedulepb.ScheduleRequest) error {
+	res, err := s.db.Exec(`UPDATE schedules SET title = ?, datetime = ?, url = ?, memo = ?, rrule = ?,
+		state = ?, ack_due = ?, notify_count = ?, alerts = ?, fired_alerts = ?, tz = ?, tags = ?, owner = ?, assignees = ?, acked_by = ?, emails = ?, due = ? WHERE id = ?`,
+		sch.Title, sch.Datetime, sch.Url, sch.Memo, sch.Rrule, sch.State, sch.AckDue, sch.NotifyCount,
+		strings.Join(sch.Alerts, ","), strings.Join(sch.FiredAlerts, ","), sch.Tz, strings.Join(sch.Tags, ","), sch.Owner,
+		strings.Join(sch.Assignees, ","), strings.Join(sch.AckedBy, ","), strings.Join(sch.Emails, ","), dueOf(sch.Datetime), sch.Id)
+	if err != nil {
+		return err
+	}
+	return affected(res)
+}
+
+func (s *SQLiteStore) Delete(id string) error {
+	res, err := s.db.Exec("DELETE FROM schedules WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return affected(res)
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) query(q string, args ...any) ([]*schedulepb.ScheduleRequest, error) {
+	rows, err := s.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*schedulepb.ScheduleRequest
+	for rows.Next() {
+		sch := &schedulepb.ScheduleRequest{}
+		var alerts, fired, tags, assignees, acked, emails string
+		if err := rows.Scan(&sch.Id, &sch.Title, &sch.Datetime, &sch.Url, &sch.Memo, &sch.Rrule,
+			&sch.State, &sch.AckDue, &sch.NotifyCount, &alerts, &fired, &sch.Tz, &tags, &sch.Owner, &assignees, &acked, &emails); err != nil {
+			return nil, err
+		}
+		sch.Alerts = splitList(alerts)
+		sch.FiredAlerts = splitList(fired)
+		sch.Tags = splitList(tags)
+		sch.Assignees = splitList(assignees)
+		sch.AckedBy = splitList(acked)
+		sch.Emails = splitList(emails)
+		list = append(list, sch)
+	}
+	return list, rows.Err()
+}
+
+func affected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Package store persists schedules for the server.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+)
+
+var ErrNotFound = errors.New("schedule not found")
+
+// Store keeps schedules in insertion order. List indexes shown to users are
+// positions in that order.
+type Store interface {
+	Add(sch *schedulepb.ScheduleRequest) error
+	List() ([]*schedulepb.ScheduleRequest, error)
+	// Query returns the schedules q selects, in insertion order.
+	Query(q Query) ([]*schedulepb.ScheduleRequest, error)
+	Get(id string) (*schedulepb.ScheduleRequest, error)
+	// FindPrefix returns every schedule whose ID starts with prefix.
+	FindPrefix(prefix string) ([]*schedulepb.ScheduleRequest, error)
+	// Update replaces the schedule with the same ID.
+	Update(sch *schedulepb.ScheduleRequest) error
+	Delete(id string) error
+	Close() error
+}
+
+// Query selects schedules by due time and pages through them. Zero fields
+// do not restrict.
+type Query struct {
+	// From and To bound the due time; From is inclusive, To exclusive.
+	// Schedules whose datetime cannot be parsed fall outside any range.
+	From, To time.Time
+	Offset   int
+	Limit    int
+}
+
+// match reports whether sch is in the range of q.
+func (q Query) match(sch *schedulepb.ScheduleRequest) bool {
+	if q.From.IsZero() && q.To.IsZero() {
+		return true
+	}
+	t, err := watcher.ParseDatetime(sch.Datetime)
+	return err == nil && (q.From.IsZero() || !t.Before(q.From)) && (q.To.IsZero() || t.Before(q.To))
+}
+
+// Checker is implemented by stores that can verify their data at startup.
+// Check returns how many damaged records were moved aside.
+type Checker interface {
+	Check() (int, error)
+}
+
+// Open opens the store of the given kind (csv or sqlite) at path.
+func Open(kind, path string) (Store, error) {
+	switch kind {
+	case "csv":
+		return NewCSV(path), nil
+	case "sqlite":
+		return OpenSQLite(path)
+	}
+	return nil, fmt.Errorf("unknown store %q (csv|sqlite)", kind)
+}
+
+// splitList reads a comma separated column. An empty column is an empty
+// list.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
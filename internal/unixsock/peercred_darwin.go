@@ -0,0 +1,15 @@
+package unixsock
+
+import "golang.org/x/sys/unix"
+
+func peerCred(fd int) (Cred, error) {
+	x, err := unix.GetsockoptXucred(fd, unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	if err != nil {
+		return Cred{}, err
+	}
+	cred := Cred{UID: int(x.Uid)}
+	if x.Ngroups > 0 {
+		cred.GID = int(x.Groups[0])
+	}
+	return cred, nil
+}
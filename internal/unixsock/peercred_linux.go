@@ -0,0 +1,11 @@
+package unixsock
+
+import "syscall"
+
+func peerCred(fd int) (Cred, error) {
+	u, err := syscall.GetsockoptUcred(fd, syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	if err != nil {
+		return Cred{}, err
+	}
+	return Cred{UID: int(u.Uid), GID: int(u.Gid), PID: int(u.Pid)}, nil
+}
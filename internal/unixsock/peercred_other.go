@@ -0,0 +1,7 @@
+//go:build !linux && !darwin
+
+package unixsock
+
+func peerCred(int) (Cred, error) {
+	return Cred{}, ErrUnsupported
+}
@@ -0,0 +1,233 @@
+// Package unixsock serves gRPC on a Unix domain socket and admits only the
+// users allowed to talk to it, identified by the kernel's peer credentials
+// (SO_PEERCRED on Linux, LOCAL_PEERCRED on macOS) rather than by anything
+// the client sends.
+package unixsock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Scheme prefixes socket paths in listen addresses and gRPC targets.
+const Scheme = "unix:"
+
+// ErrUnsupported is returned where the platform cannot report peer
+// credentials.
+var ErrUnsupported = errors.New("peer credentials are not supported on this platform")
+
+// DefaultPath is remindme/remindme.sock under $XDG_RUNTIME_DIR, or a
+// per-user directory in the temporary directory when it is unset.
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "remindme", "remindme.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("remindme-%d", os.Getuid()), "remindme.sock")
+}
+
+// Split separates a listen address into a network and an address:
+// "unix:/path/to/sock" is a socket, anything else a TCP address.
+func Split(addr string) (network, address string) {
+	if path, ok := strings.CutPrefix(addr, Scheme); ok {
+		// Accept the URL form unix:///path as well.
+		if strings.HasPrefix(path, "//") {
+			path = strings.TrimPrefix(path, "//")
+		}
+		return "unix", path
+	}
+	return "tcp", addr
+}
+
+// Discover returns the gRPC target of a server listening on DefaultPath,
+// or "" if there is no socket there.
+func Discover() string {
+	path := DefaultPath()
+	if fi, err := os.Stat(path); err != nil || fi.Mode()&fs.ModeSocket == 0 {
+		return ""
+	}
+	return Scheme + path
+}
+
+// Listen creates the socket at path, readable and writable by its owner
+// only, inside a directory only the owner can enter. A socket left behind
+// by a server that is no longer running is replaced.
+//
+// A shared socket is for servers that admit other users with Authorize:
+// the directory can be passed through (0711) and the socket opened (0666)
+// by anyone, so that the peer credentials decide who gets in. Every
+// directory above it must be passable too, which rules out the owner-only
+// $XDG_RUNTIME_DIR.
+func Listen(path string, shared bool) (net.Listener, error) {
+	dir := filepath.Dir(path)
+	dirMode, sockMode := fs.FileMode(0o700), fs.FileMode(0o600)
+	if shared {
+		dirMode, sockMode = 0o711, 0o666
+		if err := passable(filepath.Dir(dir)); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, err
+	}
+	// Chmod fails unless we own the directory, so a directory planted by
+	// another user in a shared location is refused.
+	if err := os.Chmod(dir, dirMode); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Lstat(path); err == nil {
+		conn, err := net.DialTimeout("unix", path, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, sockMode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
+
+// passable checks that other users can pass through dir and every
+// directory above it that exists.
+func passable(dir string) error {
+	for {
+		if fi, err := os.Stat(dir); err == nil && fi.Mode().Perm()&0o001 == 0 {
+			return fmt.Errorf("other users cannot reach a socket under %s (mode %o); listen on a path such as /run/remindme/remindme.sock", dir, fi.Mode().Perm())
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// Cred is the identity of the process at the other end of a socket.
+type Cred struct {
+	UID int
+	GID int
+	PID int // 0 where the platform does not report it
+}
+
+// PeerCred reads the credentials of the peer of conn.
+func PeerCred(conn *net.UnixConn) (Cred, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return Cred{}, err
+	}
+	var cred Cred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = peerCred(int(fd))
+	}); err != nil {
+		return Cred{}, err
+	}
+	return cred, credErr
+}
+
+// AuthInfo carries the peer credentials of a connection into request
+// contexts, where peer.FromContext finds it.
+type AuthInfo struct {
+	credentials.CommonAuthInfo
+	Cred Cred
+}
+
+func (AuthInfo) AuthType() string {
+	return "peercred"
+}
+
+// Credentials returns transport credentials that record the peer
+// credentials of every accepted socket connection. The socket never
+// leaves the machine, so connections count as private.
+func Credentials() credentials.TransportCredentials {
+	return peerCreds{}
+}
+
+type peerCreds struct{}
+
+func (peerCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, nil, fmt.Errorf("unixsock: %T is not a unix socket connection", conn)
+	}
+	cred, err := PeerCred(uc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, AuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		Cred:           cred,
+	}, nil
+}
+
+func (peerCreds) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, AuthInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity}}, nil
+}
+
+func (peerCreds) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "peercred"}
+}
+
+func (c peerCreds) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (peerCreds) OverrideServerName(string) error {
+	return nil
+}
+
+// Authorize returns interceptors that reject calls from users not in
+// uids with PermissionDenied. They need the server to use Credentials.
+func Authorize(uids []int) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	check := func(ctx context.Context) error {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return status.Error(codes.PermissionDenied, "unknown peer")
+		}
+		info, ok := p.AuthInfo.(AuthInfo)
+		if !ok {
+			return status.Error(codes.PermissionDenied, "peer credentials unavailable")
+		}
+		if !slices.Contains(uids, info.Cred.UID) {
+			return status.Errorf(codes.PermissionDenied, "uid %d is not allowed", info.Cred.UID)
+		}
+		return nil
+	}
+	unary := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+	return unary, stream
+}
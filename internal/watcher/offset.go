@@ -0,0 +1,88 @@
+package watcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var offsetUnits = []struct {
+	suffix byte
+	unit   time.Duration
+}{
+	{'d', 24 * time.Hour},
+	{'h', time.Hour},
+	{'m', time.Minute},
+	{'s', time.Second},
+}
+
+// ParseOffset parses an alert offset relative to a schedule's Datetime,
+// such as "-1d", "-1h30m" or "0". Besides the units of time.ParseDuration
+// it accepts d for days.
+func ParseOffset(s string) (time.Duration, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if s == "0" {
+		return 0, nil
+	}
+
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid offset %q", orig)
+	}
+
+	var d time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, fmt.Errorf("invalid offset %q", orig)
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset %q", orig)
+		}
+		unit := time.Duration(0)
+		for _, u := range offsetUnits {
+			if s[i] == u.suffix {
+				unit = u.unit
+			}
+		}
+		if unit == 0 {
+			return 0, fmt.Errorf("invalid offset %q: unknown unit %q", orig, s[i])
+		}
+		d += time.Duration(n) * unit
+		s = s[i+1:]
+	}
+	return sign * d, nil
+}
+
+// FormatOffset is the inverse of ParseOffset, e.g. -90*time.Minute
+// becomes "-1h30m".
+func FormatOffset(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	for _, u := range offsetUnits {
+		if n := d / u.unit; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10))
+			b.WriteByte(u.suffix)
+			d -= n * u.unit
+		}
+	}
+	return b.String()
+}
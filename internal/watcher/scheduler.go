@@ -0,0 +1,147 @@
+package watcher
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Scheduler keeps every pending reminder in a min-heap ordered by due time
+// and waits on a single timer for the earliest one. When an entry comes due
+// it is removed from the heap and handed to the fire callback.
+type Scheduler struct {
+	mu      sync.Mutex
+	queue   queue
+	index   map[string]*entry
+	timer   *time.Timer
+	fire    func(id string)
+	stopped bool
+}
+
+type entry struct {
+	id  string
+	at  time.Time
+	pos int
+}
+
+func NewScheduler(fire func(id string)) *Scheduler {
+	s := &Scheduler{
+		index: make(map[string]*entry),
+		fire:  fire,
+	}
+	s.timer = time.AfterFunc(time.Hour, s.run)
+	s.timer.Stop()
+	return s
+}
+
+// Add arms id to fire at the given time. If id is already armed it is
+// rescheduled instead.
+func (s *Scheduler) Add(id string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.index[id]; ok {
+		e.at = at
+		heap.Fix(&s.queue, e.pos)
+	} else {
+		e := &entry{id: id, at: at}
+		s.index[id] = e
+		heap.Push(&s.queue, e)
+	}
+	s.rearm()
+}
+
+// Reschedule moves an armed id to a new time. It reports false if id is not
+// armed.
+func (s *Scheduler) Reschedule(id string, at time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.index[id]
+	if !ok {
+		return false
+	}
+	e.at = at
+	heap.Fix(&s.queue, e.pos)
+	s.rearm()
+	return true
+}
+
+// Remove disarms id. It reports false if id was not armed.
+func (s *Scheduler) Remove(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.index[id]
+	if !ok {
+		return false
+	}
+	heap.Remove(&s.queue, e.pos)
+	delete(s.index, id)
+	s.rearm()
+	return true
+}
+
+// Stop disarms the timer. Pending entries are kept but never fire.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	s.timer.Stop()
+}
+
+func (s *Scheduler) rearm() {
+	if s.stopped || len(s.queue) == 0 {
+		s.timer.Stop()
+		return
+	}
+	s.timer.Reset(time.Until(s.queue[0].at))
+}
+
+func (s *Scheduler) run() {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	var due []string
+	for len(s.queue) > 0 && !s.queue[0].at.After(now) {
+		e := heap.Pop(&s.queue).(*entry)
+		delete(s.index, e.id)
+		due = append(due, e.id)
+	}
+	s.rearm()
+	s.mu.Unlock()
+
+	for _, id := range due {
+		s.fire(id)
+	}
+}
+
+type queue []*entry
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].pos = i
+	q[j].pos = j
+}
+
+func (q *queue) Push(x any) {
+	e := x.(*entry)
+	e.pos = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *queue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.pos = -1
+	*q = old[:n-1]
+	return e
+}
@@ -3,35 +3,38 @@ package watcher
 import (
 	"fmt"
 	"time"
-
-	schedulepb "github.com/je0ng3/remindme-cli/api/proto/schedulepb"
-	"github.com/je0ng3/remindme-cli/internal/notify"
 )
 
-type ScheduleChecker interface {
-	Exists(id string) bool
-	Delete(id string) error
-}
+// MissedPolicy decides what happens to a reminder whose Datetime passed
+// while the server was down.
+type MissedPolicy int
 
-func Watch(req *schedulepb.ScheduleRequest, checker ScheduleChecker) {
-	layout := "2006-01-02 15:04"
-	t, err := time.ParseInLocation(layout, req.Datetime, time.Local)
-	if err != nil {
-		fmt.Println("날짜 포맷 불일치:", err)
-	}
+const (
+	// MissedFire sends the notification late, as soon as the server is back.
+	MissedFire MissedPolicy = iota
+	// MissedSkip drops the reminder and only leaves a log entry.
+	MissedSkip
+)
 
-	duration := time.Until(t)
-	if duration <= 0 {
-		return
+func ParseMissedPolicy(s string) (MissedPolicy, error) {
+	switch s {
+	case "fire":
+		return MissedFire, nil
+	case "skip":
+		return MissedSkip, nil
 	}
-	
-	time.Sleep(duration)
+	return 0, fmt.Errorf("unknown missed policy %q (fire|skip)", s)
+}
+
+// Layout is the wall-clock format users write datetimes in. Stored
+// schedules use RFC 3339 instead.
+const Layout = "2006-01-02 15:04"
 
-	if checker.Exists(req.Id) {
-		err = notify.Send(req.Title, req.Memo, req.Url)
-		if err != nil {
-			fmt.Println("알림 전송 실패:", err)
-		}
-		checker.Delete(req.Id)
+// ParseDatetime parses the Datetime field of a schedule. Schedules written
+// before time zones were stored use Layout and are read in the local zone.
+func ParseDatetime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
 	}
-}
\ No newline at end of file
+	return time.ParseInLocation(Layout, s, time.Local)
+}
@@ -0,0 +1,337 @@
+// Package when parses the loose date phrases people type into the add
+// template: absolute times ("2025-07-22 18:00", "2025-07-22T18:00+09:00"),
+// relative ones ("in 90m", "30분 후") and day/time phrases in English or
+// Korean ("tomorrow 9am", "next fri 14:00", "내일 오후 3시",
+// "다음주 월요일 10시").
+package when
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/zone"
+)
+
+// DefaultHour is the time of day used when a phrase names a day but no time.
+const DefaultHour = 9
+
+var absoluteLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "일": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "월": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday, "화": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "수": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday, "목": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "금": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "토": time.Saturday,
+}
+
+var durationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second, "초": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute, "분": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour, "시간": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour, "일": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour, "주": 7 * 24 * time.Hour,
+}
+
+// Each pattern is matched against the start of the unread input. English
+// words end at \b; Korean ones need no boundary since particles attach.
+var (
+	reSpace     = regexp.MustCompile(`^[\s,]+`)
+	reFiller    = regexp.MustCompile(`^(?:at|on|에)(?:\b|\s|$)`)
+	reNow       = regexp.MustCompile(`^(?:now\b|지금)`)
+	reIn        = regexp.MustCompile(`^in\b`)
+	reDuration  = regexp.MustCompile(`^(\d+)\s*(seconds|second|secs|sec|minutes|minute|mins|min|hours|hour|hrs|hr|days|day|weeks|week|시간|[smhdw초분일주])`)
+	reAfter     = regexp.MustCompile(`^(?:후|뒤|later\b)`)
+	reDayWord   = regexp.MustCompile(`^(today|tonight|tomorrow|tmrw|tmr|오늘|내일|모레)`)
+	reWeekWord  = regexp.MustCompile(`^(next|this|다다음\s*주|다음\s*주|이번\s*주)\s*`)
+	reWeekday   = regexp.MustCompile(`^(?:(sunday|sun|monday|mon|tuesday|tues|tue|wednesday|wed|thursday|thurs|thur|thu|friday|fri|saturday|sat)\b|([일월화수목금토])요일)`)
+	reISODate   = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})`)
+	reSlashDate = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})\b`)
+	reKoDate    = regexp.MustCompile(`^(?:(\d{4})\s*년\s*)?(\d{1,2})\s*월\s*(\d{1,2})\s*일`)
+	reMeridiem  = regexp.MustCompile(`^(오전|오후|새벽|아침|낮|저녁|밤)`)
+	reClockAMPM = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm|a\.m\.|p\.m\.)`)
+	reClock     = regexp.MustCompile(`^(\d{1,2}):(\d{2})\b`)
+	reKoClock   = regexp.MustCompile(`^(\d{1,2})\s*시(?:\s*(반)|\s*(\d{1,2})\s*분)?`)
+	reNoon      = regexp.MustCompile(`^(noon\b|midnight\b|정오|자정)`)
+)
+
+// phrase accumulates the parts of the input as they are read.
+type phrase struct {
+	dur       time.Duration
+	relative  bool
+	days      int  // today/tomorrow offset
+	dayWord   bool // days was set
+	weeks     int  // 0 = upcoming weekday, 1 = next week, ...
+	weekday   time.Weekday
+	byWeekday bool
+	year      int
+	month     time.Month
+	day       int
+	byDate    bool
+	hour, min int
+	clock     bool
+	pm, am    bool
+}
+
+// Parse resolves s relative to now and returns the time in now's location.
+//
+// A time with no day is today if it is still ahead, otherwise tomorrow.
+// A day with no time is at DefaultHour. A bare weekday is the next such day
+// (today if the time is still ahead); "next" or "다음주" picks the weekday
+// in the following Monday-based week. Hours without am/pm or 오전/오후 are
+// read on a 24-hour clock, where 24:00 is midnight at the end of the day.
+//
+// A wall time skipped by a daylight saving transition in now's location
+// is a *zone.NonexistentError; one that occurs twice is the earlier
+// instant.
+func Parse(s string, now time.Time) (time.Time, error) {
+	orig := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	loc := now.Location()
+	for _, layout := range absoluteLayouts {
+		for _, v := range []string{s, strings.ToUpper(s)} {
+			t, err := time.Parse(layout, v)
+			if err != nil {
+				continue
+			}
+			if strings.Contains(layout, "Z07") {
+				return t.In(loc), nil
+			}
+			// A wall time is placed in loc, where DST may skip or repeat it.
+			y, m, d := t.Date()
+			hh, mm, ss := t.Clock()
+			t, _, err = zone.Wall(y, m, d, hh, mm, ss, loc)
+			return t, err
+		}
+	}
+
+	var p phrase
+	if err := p.read(s); err != nil {
+		return time.Time{}, fmt.Errorf("cannot understand %q: %v", orig, err)
+	}
+	return p.resolve(now)
+}
+
+func (p *phrase) read(s string) error {
+	for s != "" {
+		var m []string
+		match := func(re *regexp.Regexp) bool {
+			m = re.FindStringSubmatch(s)
+			if m != nil {
+				s = s[len(m[0]):]
+			}
+			return m != nil
+		}
+
+		switch {
+		case match(reSpace), match(reFiller), match(reAfter):
+		case match(reNow):
+			p.relative = true
+		case match(reIn):
+			p.relative = true
+		case match(reISODate):
+			p.year, _ = strconv.Atoi(m[1])
+			if err := p.setDate(m[2], m[3]); err != nil {
+				return err
+			}
+		case match(reKoDate):
+			if m[1] != "" {
+				p.year, _ = strconv.Atoi(m[1])
+			}
+			if err := p.setDate(m[2], m[3]); err != nil {
+				return err
+			}
+		case match(reSlashDate):
+			if err := p.setDate(m[1], m[2]); err != nil {
+				return err
+			}
+		case match(reDayWord):
+			p.dayWord = true
+			switch m[1] {
+			case "tomorrow", "tmrw", "tmr", "내일":
+				p.days = 1
+			case "모레":
+				p.days = 2
+			case "tonight":
+				if !p.clock {
+					p.hour, p.clock = 20, true
+				}
+			}
+		case match(reWeekWord):
+			switch strings.Join(strings.Fields(m[1]), "") {
+			case "next", "다음주":
+				p.weeks = 1
+			case "다다음주":
+				p.weeks = 2
+			}
+			if !reWeekday.MatchString(s) {
+				return fmt.Errorf("expected a weekday after %q", strings.TrimSpace(m[1]))
+			}
+		case match(reWeekday):
+			p.byWeekday = true
+			p.weekday = weekdays[m[1]+m[2]]
+		case match(reDuration):
+			n, _ := strconv.Atoi(m[1])
+			p.dur += time.Duration(n) * durationUnits[m[2]]
+			p.relative = true
+		case match(reClockAMPM):
+			if err := p.setClock(m[1], m[2]); err != nil {
+				return err
+			}
+			if strings.HasPrefix(m[3], "p") {
+				p.pm = true
+			} else {
+				p.am = true
+			}
+		case match(reClock):
+			if err := p.setClock(m[1], m[2]); err != nil {
+				return err
+			}
+		case match(reKoClock):
+			min := m[3]
+			if m[2] != "" {
+				min = "30"
+			}
+			if err := p.setClock(m[1], min); err != nil {
+				return err
+			}
+		case match(reNoon):
+			p.clock = true
+			p.hour, p.min = 12, 0
+			if m[1] == "midnight" || m[1] == "자정" {
+				p.hour = 0
+				if !p.dayWord && !p.byDate && !p.byWeekday {
+					p.days, p.dayWord = 1, true
+				}
+			}
+		case match(reMeridiem):
+			switch m[1] {
+			case "오후", "낮", "저녁", "밤":
+				p.pm = true
+			default:
+				p.am = true
+			}
+		default:
+			word := strings.Fields(s)[0]
+			return fmt.Errorf("unexpected %q", word)
+		}
+	}
+	return nil
+}
+
+func (p *phrase) setDate(month, day string) error {
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if m < 1 || m > 12 || d < 1 || d > 31 {
+		return fmt.Errorf("invalid date %s/%s", month, day)
+	}
+	p.month, p.day, p.byDate = time.Month(m), d, true
+	return nil
+}
+
+func (p *phrase) setClock(hour, min string) error {
+	h, _ := strconv.Atoi(hour)
+	m := 0
+	if min != "" {
+		m, _ = strconv.Atoi(min)
+	}
+	if h > 24 || m > 59 || h == 24 && m > 0 {
+		return fmt.Errorf("invalid time %s:%02d", hour, m)
+	}
+	p.hour, p.min, p.clock = h, m, true
+	return nil
+}
+
+func (p *phrase) resolve(now time.Time) (time.Time, error) {
+	loc := now.Location()
+	hour := p.hour
+	// 24:00 is the end of the day, which is midnight of the next one.
+	endOfDay := false
+	if p.clock {
+		switch {
+		case p.pm && hour < 12:
+			hour += 12
+		case p.am && hour == 12:
+			hour = 0
+		case hour == 24:
+			hour, endOfDay = 0, true
+		}
+	}
+	if (p.am || p.pm) && !p.clock {
+		return time.Time{}, fmt.Errorf("am/pm without a time")
+	}
+
+	if p.relative && !p.dayWord && !p.byDate && !p.byWeekday && !p.clock {
+		return now.Add(p.dur), nil
+	}
+
+	y, m, d := now.Date()
+	base := now
+	if p.relative {
+		base = now.Add(p.dur)
+		y, m, d = base.Date()
+	}
+	switch {
+	case p.byDate:
+		if p.year != 0 {
+			y = p.year
+		}
+		m, d = p.month, p.day
+	case p.byWeekday:
+		ahead := (int(p.weekday) - int(now.Weekday()) + 7) % 7
+		if p.weeks > 0 {
+			// Monday-based weeks: find this week's Monday, then step ahead.
+			sinceMonday := (int(now.Weekday()) + 6) % 7
+			ahead = 7*p.weeks - sinceMonday + (int(p.weekday)+6)%7
+		}
+		d += ahead
+	case p.dayWord:
+		d += p.days
+	}
+
+	explicitDay := p.byDate || p.byWeekday || p.dayWord || p.relative
+	if !p.clock {
+		if !explicitDay {
+			return time.Time{}, fmt.Errorf("no date or time")
+		}
+		hour, p.min = DefaultHour, 0
+	}
+	// Day arithmetic is done on a UTC date so that a DST transition on
+	// the way cannot shift the wall clock; the zone is applied last.
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if p.byDate && (date.Month() != p.month || date.Day() != p.day) {
+		return time.Time{}, fmt.Errorf("invalid date %d-%02d-%02d", y, p.month, p.day)
+	}
+	if endOfDay {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	at := time.Date(date.Year(), date.Month(), date.Day(), hour, p.min, 0, 0, loc)
+	if !at.After(now) {
+		switch {
+		case p.byDate && p.year == 0:
+			date = date.AddDate(1, 0, 0)
+		case p.byWeekday && p.weeks == 0:
+			date = date.AddDate(0, 0, 7)
+		case !explicitDay:
+			date = date.AddDate(0, 0, 1)
+		}
+	}
+	t, _, err := zone.Wall(date.Year(), date.Month(), date.Day(), hour, p.min, 0, loc)
+	return t, err
+}
@@ -0,0 +1,125 @@
+// Package zone resolves wall-clock times in IANA time zones, including the
+// times that daylight saving transitions skip or repeat.
+package zone
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Load returns the location for an IANA zone name. An empty name is the
+// local zone.
+func Load(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// LocalName returns the IANA name of the local zone, taken from $TZ or the
+// /etc/localtime symlink. It returns "" when the name cannot be found.
+func LocalName() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return strings.TrimPrefix(tz, ":")
+	}
+	if name := time.Local.String(); name != "Local" {
+		return name
+	}
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+		return name
+	}
+	return ""
+}
+
+// candidates returns every instant whose wall clock in loc reads
+// y-m-d hh:mm:ss, in chronological order. A wall time skipped by a DST
+// transition has none, and one repeated by it has two.
+func candidates(y int, m time.Month, d, hh, mm, ss int, loc *time.Location) []time.Time {
+	wall := time.Date(y, m, d, hh, mm, ss, 0, time.UTC)
+
+	// Offsets in effect a day before and after cover any single transition.
+	var out []time.Time
+	for _, probe := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := probe.In(loc).Zone()
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		y2, m2, d2 := t.Date()
+		h2, min2, s2 := t.Clock()
+		if y2 != y || m2 != m || d2 != d || h2 != hh || min2 != mm || s2 != ss {
+			continue
+		}
+		if len(out) == 1 && out[0].Equal(t) {
+			continue
+		}
+		out = append(out, t)
+	}
+	if len(out) == 2 && out[1].Before(out[0]) {
+		out[0], out[1] = out[1], out[0]
+	}
+	return out
+}
+
+// Date is like time.Date but resolves DST transitions the way RFC 5545
+// does for recurrences: a skipped wall time moves forward by the length of
+// the gap, and a repeated one means its first occurrence.
+func Date(y int, m time.Month, d, hh, mm, ss int, loc *time.Location) time.Time {
+	// Normalise overflowing fields such as day 32 first.
+	n := time.Date(y, m, d, hh, mm, ss, 0, time.UTC)
+	y, m, d = n.Date()
+	hh, mm, ss = n.Clock()
+
+	if c := candidates(y, m, d, hh, mm, ss, loc); len(c) > 0 {
+		return c[0]
+	}
+	// In a gap: read the wall time with the offset from before the gap.
+	_, before := n.Add(-24 * time.Hour).In(loc).Zone()
+	return n.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+// NonexistentError reports a wall time skipped by a DST transition.
+type NonexistentError struct {
+	Wall string
+	Zone string
+}
+
+func (e *NonexistentError) Error() string {
+	return fmt.Sprintf("%s does not exist in %s (skipped by daylight saving time)", e.Wall, e.Zone)
+}
+
+// Resolve parses a wall time written in layout and places it in loc.
+// A time skipped by a DST transition is an error. For a time that occurs
+// twice the earlier instant is returned and ambiguous is true.
+func Resolve(layout, value string, loc *time.Location) (t time.Time, ambiguous bool, err error) {
+	wall, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	y, m, d := wall.Date()
+	hh, mm, ss := wall.Clock()
+	t, ambiguous, err = Wall(y, m, d, hh, mm, ss, loc)
+	if e, ok := err.(*NonexistentError); ok {
+		e.Wall = value
+	}
+	return t, ambiguous, err
+}
+
+// Wall is Resolve for a wall time given by its fields, which must be in
+// range.
+func Wall(y int, m time.Month, d, hh, mm, ss int, loc *time.Location) (t time.Time, ambiguous bool, err error) {
+	c := candidates(y, m, d, hh, mm, ss, loc)
+	if len(c) == 0 {
+		wall := fmt.Sprintf("%04d-%02d-%02d %02d:%02d", y, m, d, hh, mm)
+		return time.Time{}, false, &NonexistentError{Wall: wall, Zone: loc.String()}
+	}
+	return c[0], len(c) > 1, nil
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/notify"
+	"github.com/je0ng3/remindme-cli/internal/server"
+	"github.com/je0ng3/remindme-cli/internal/store"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+)
+
+type recordingNotifier chan notify.Notification
+
+func (r recordingNotifier) Notify(n notify.Notification) error {
+	r <- n
+	return nil
+}
+
+// firedServer returns a server whose only schedule, "due-id", is overdue
+// and fires as soon as it is restored.
+func firedServer(t *testing.T, interval time.Duration, limit int) (*server.ScheduleServer, store.Store, recordingNotifier) {
+	path := filepath.Join(t.TempDir(), "schedules.csv")
+	if err := os.WriteFile(path, []byte("due-id,Overdue,2001-01-01 09:00,,\n"), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+	st := store.NewCSV(path)
+	notes := make(recordingNotifier, 10)
+	s := server.NewServer(st, server.WithNotifier(notes), server.WithRenotify(interval, limit))
+	if _, err := s.Restore(watcher.MissedFire); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	waitNotification(t, notes)
+	return s, st, notes
+}
+
+func waitNotification(t *testing.T, notes recordingNotifier) {
+	t.Helper()
+	select {
+	case <-notes:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a notification")
+	}
+}
+
+func waitGone(t *testing.T, st store.Store, id string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if _, err := st.Get(id); err != nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %s to be removed", id)
+}
+
+func TestAck_RenotifiesUpToLimit(t *testing.T) {
+	_, st, notes := firedServer(t, 30*time.Millisecond, 2)
+
+	sch, err := st.Get("due-id")
+	if err != nil {
+		t.Fatalf("Expected fired reminder to be kept: %v", err)
+	}
+	if sch.State != server.StateAwaitingAck {
+		t.Errorf("Expected awaiting_ack, got %q", sch.State)
+	}
+
+	waitNotification(t, notes)
+	waitNotification(t, notes)
+	waitGone(t, st, "due-id")
+	select {
+	case <-notes:
+		t.Error("Expected no notification after the limit")
+	default:
+	}
+}
+
+func TestAck_DoneAndSnooze(t *testing.T) {
+	s, st, notes := firedServer(t, time.Hour, 3)
+	ctx := context.TODO()
+
+	if _, err := s.SnoozeSchedule(ctx, &schedulepb.SnoozeRequest{Id: "due-id", Duration: "soon"}); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+	if _, err := s.SnoozeSchedule(ctx, &schedulepb.SnoozeRequest{Id: "due-id", Duration: "20ms"}); err != nil {
+		t.Fatalf("SnoozeSchedule failed: %v", err)
+	}
+	if sch, _ := st.Get("due-id"); sch.State != server.StateSnoozed {
+		t.Errorf("Expected snoozed, got %q", sch.State)
+	}
+	waitNotification(t, notes)
+
+	if _, err := s.AckSchedule(ctx, &schedulepb.ScheduleId{Id: "due-id"}); err != nil {
+		t.Fatalf("AckSchedule failed: %v", err)
+	}
+	waitGone(t, st, "due-id")
+	if _, err := s.AckSchedule(ctx, &schedulepb.ScheduleId{Id: "due-id"}); err == nil {
+		t.Error("expected error acknowledging a removed reminder")
+	}
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/server"
+	"github.com/je0ng3/remindme-cli/internal/store"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+)
+
+func TestOffsets(t *testing.T) {
+	for in, want := range map[string]string{
+		"-1d":    "-1d",
+		"-90m":   "-1h30m",
+		"0":      "0",
+		"-1d12h": "-1d12h",
+		"+5m":    "5m",
+	} {
+		d, err := watcher.ParseOffset(in)
+		if err != nil {
+			t.Errorf("ParseOffset(%q) failed: %v", in, err)
+			continue
+		}
+		if got := watcher.FormatOffset(d); got != want {
+			t.Errorf("FormatOffset(ParseOffset(%q)) = %q, want %q", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "-", "10", "-5x", "m5"} {
+		if _, err := watcher.ParseOffset(in); err == nil {
+			t.Errorf("ParseOffset(%q): expected error, got nil", in)
+		}
+	}
+}
+
+func TestAlerts_FireOnceAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.csv")
+	due := time.Now().Add(time.Hour).Format(watcher.Layout)
+	row := fmt.Sprintf("meet-id,Meeting,%s,,,,,,,\"-2h,-30m\",\n", due)
+	if err := os.WriteFile(path, []byte(row), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+	st := store.NewCSV(path)
+
+	notes := make(recordingNotifier, 10)
+	s := server.NewServer(st, server.WithNotifier(notes))
+	if _, err := s.Restore(watcher.MissedFire); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	select {
+	case n := <-notes:
+		if !strings.Contains(n.Title, "2시간 전") {
+			t.Errorf("Unexpected alert title %q", n.Title)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the missed lead alert")
+	}
+
+	var sch *schedulepb.ScheduleRequest
+	for i := 0; i < 100; i++ {
+		sch, _ = st.Get("meet-id")
+		if len(sch.FiredAlerts) == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(sch.FiredAlerts) != 1 || sch.FiredAlerts[0] != "-2h" {
+		t.Fatalf("Expected -2h to be recorded as fired, got %v", sch.FiredAlerts)
+	}
+
+	list, _ := s.ListSchedules(context.TODO(), &schedulepb.ListSchedulesRequest{})
+	dueAt, _ := watcher.ParseDatetime(due)
+	if want := dueAt.Add(-30 * time.Minute).Format(time.RFC3339); list.Schedules[0].NextAlert != want {
+		t.Errorf("Expected next alert %s, got %s", want, list.Schedules[0].NextAlert)
+	}
+
+	// A second server on the same store must not send the alert again.
+	again := make(recordingNotifier, 10)
+	s2 := server.NewServer(st, server.WithNotifier(again))
+	if _, err := s2.Restore(watcher.MissedFire); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	select {
+	case n := <-again:
+		t.Errorf("Alert repeated after restart: %v", n)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAddSchedule_RejectsAlertAfterEvent(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+
+	_, err := s.AddSchedule(context.TODO(), &schedulepb.ScheduleRequest{
+		Title:    "Late",
+		Datetime: "2999-01-01 09:00",
+		Alerts:   []string{"+10m"},
+	})
+	if err == nil {
+		t.Fatal("expected error for an alert after the schedule time")
+	}
+}
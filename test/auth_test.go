@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuth_Users(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	users := auth.Open(path)
+
+	if list, err := users.List(); err != nil || len(list) != 0 {
+		t.Fatalf("List with no file = %v, %v; want no users", list, err)
+	}
+	token, err := users.Add("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Add("alice"); err == nil {
+		t.Error("adding alice twice: want error")
+	}
+	if _, err := users.Add("bad name"); err == nil {
+		t.Error("name with a space: want error")
+	}
+
+	// Another process, like "remindserver user add", sees the same users.
+	other := auth.Open(path)
+	if name, ok, _ := other.Authenticate(token); !ok || name != "alice" {
+		t.Errorf("Authenticate = %q, %v; want alice", name, ok)
+	}
+	if _, ok, _ := other.Authenticate(token + "x"); ok {
+		t.Error("wrong token accepted")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) == "" || strings.Contains(string(data), token) {
+		t.Errorf("user file should hold a hash, not the token: %q", data)
+	}
+	if fi, _ := os.Stat(path); fi.Mode().Perm() != 0o600 {
+		t.Errorf("user file mode = %o, want 600", fi.Mode().Perm())
+	}
+
+	if err := users.Revoke("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := other.Authenticate(token); ok {
+		t.Error("revoked token accepted")
+	}
+	if err := users.Revoke("alice"); err == nil {
+		t.Error("revoking twice: want error")
+	}
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestAuth_NoUsersRefusesCalls(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	path := filepath.Join(t.TempDir(), "users.csv")
+	users := auth.Open(path)
+	unary, stream := auth.Interceptors(users)
+	client := startTestServer(t, s, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+
+	list := func(ctx context.Context) error {
+		_, err := client.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+		return err
+	}
+	if err := list(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("no user file: got %v, want Unauthenticated", err)
+	}
+	alice, _ := users.Add("alice")
+	if err := list(withToken(alice)); err != nil {
+		t.Fatalf("alice: %v", err)
+	}
+	// Revoking the last user or deleting the file does not turn
+	// authentication off.
+	users.Revoke("alice")
+	if err := list(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("last user revoked: got %v, want Unauthenticated", err)
+	}
+	bob, _ := users.Add("bob")
+	os.Remove(path)
+	if err := list(withToken(bob)); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("user file deleted: got %v, want Unauthenticated", err)
+	}
+}
+
+func TestAuth_ScopesSchedulesToOwner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.csv")
+	s := server.NewSchedulerServer(path, server.WithAdmin("alice"))
+
+	// A schedule from before authentication was turned on has no owner,
+	// and belongs to the admin.
+	if _, err := s.AddSchedule(context.Background(), &schedulepb.ScheduleRequest{Title: "Shared", Datetime: "2999-01-01 09:00"}); err != nil {
+		t.Fatal(err)
+	}
+
+	users := auth.Open(filepath.Join(t.TempDir(), "users.csv"))
+	alice, _ := users.Add("alice")
+	bob, _ := users.Add("bob")
+	unary, stream := auth.Interceptors(users)
+	client := startTestServer(t, s, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+
+	_, err := client.ListSchedules(context.Background(), &schedulepb.ListSchedulesRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("without a token: got %v, want Unauthenticated", err)
+	}
+	_, err = client.ListSchedules(withToken("rmd_nope"), &schedulepb.ListSchedulesRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("with a bad token: got %v, want Unauthenticated", err)
+	}
+
+	if _, err := client.AddSchedule(withToken(alice), &schedulepb.ScheduleRequest{Title: "Alice's", Datetime: "2999-01-01 10:00", Owner: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	titles := func(token string) []string {
+		t.Helper()
+		res, err := client.ListSchedules(withToken(token), &schedulepb.ListSchedulesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, sch := range res.Schedules {
+			out = append(out, sch.Title+"/"+sch.Owner)
+		}
+		return out
+	}
+	if got := titles(alice); len(got) != 2 || got[0] != "Shared/" || got[1] != "Alice's/alice" {
+		t.Errorf("alice sees %v, want the ownerless schedule and her own", got)
+	}
+	if got := titles(bob); len(got) != 0 {
+		t.Errorf("bob sees %v, want nothing", got)
+	}
+
+	res, _ := client.ListSchedules(withToken(alice), &schedulepb.ListSchedulesRequest{})
+	id := res.Schedules[1].Id
+	for name, call := range map[string]func() error{
+		"delete by id": func() error {
+			_, err := client.DeleteScheduleById(withToken(bob), &schedulepb.ScheduleId{Id: id})
+			return err
+		},
+		"get": func() error {
+			_, err := client.GetSchedule(withToken(bob), &schedulepb.ScheduleId{Id: id[:8]})
+			return err
+		},
+		"update": func() error {
+			_, err := client.UpdateSchedule(withToken(bob), &schedulepb.UpdateScheduleRequest{Id: id, Schedule: &schedulepb.ScheduleRequest{Title: "Mine now"}})
+			return err
+		},
+	} {
+		if err := call(); status.Code(err) != codes.NotFound {
+			t.Errorf("bob %s of alice's schedule: got %v, want NotFound", name, err)
+		}
+	}
+	// Bob has no index 1; neither schedule is his to delete.
+	if r, err := client.DeleteSchedule(withToken(bob), &schedulepb.ScheduleIdx{Idx: 1}); err != nil || r.Message != "Invalid index" {
+		t.Errorf("bob delete index 1 = %v, %v; want Invalid index", r, err)
+	}
+	if _, err := client.DeleteScheduleById(withToken(alice), &schedulepb.ScheduleId{Id: id}); err != nil {
+		t.Errorf("alice deletes her own schedule: %v", err)
+	}
+}
+
+func TestAuth_WatchEventsScoped(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	users := auth.Open(filepath.Join(t.TempDir(), "users.csv"))
+	alice, _ := users.Add("alice")
+	bob, _ := users.Add("bob")
+	unary, stream := auth.Interceptors(users)
+	client := startTestServer(t, s, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+
+	ctx, cancel := context.WithTimeout(withToken(bob), 5*time.Second)
+	defer cancel()
+	events, err := client.WatchEvents(ctx, &schedulepb.WatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := events.Header(); err != nil {
+		t.Fatal(err)
+	}
+
+	client.AddSchedule(withToken(alice), &schedulepb.ScheduleRequest{Title: "Alice's", Datetime: "2999-01-01 10:00"})
+	client.AddSchedule(withToken(bob), &schedulepb.ScheduleRequest{Title: "Bob's", Datetime: "2999-01-01 11:00"})
+
+	ev, err := events.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Schedule.Title != "Bob's" {
+		t.Errorf("bob received an event for %q, want only his own", ev.Schedule.Title)
+	}
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/certs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestCerts_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	_, err := certs.Init(certs.Request{
+		Dir:     dir,
+		Hosts:   []string{"localhost", "127.0.0.1"},
+		Clients: []string{"alice"},
+		Valid:   time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	serverTLS, err := certs.ServerConfig(file(certs.CertFile("server")), file(certs.KeyFile("server")), file(certs.CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS)))
+	schedulepb.RegisterSchedulerServer(g, s)
+	go g.Serve(lis)
+	defer g.Stop()
+
+	call := func(caFile, certFile, keyFile string) error {
+		clientTLS, err := certs.ClientConfig(caFile, certFile, keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = schedulepb.NewSchedulerClient(conn).ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+		return err
+	}
+
+	if err := call(file(certs.CAFile), file(certs.CertFile("alice")), file(certs.KeyFile("alice"))); err != nil {
+		t.Errorf("with a client certificate: %v", err)
+	}
+	if err := call(file(certs.CAFile), "", ""); err == nil {
+		t.Error("without a client certificate: want error")
+	}
+
+	// A second CA's certificates are not trusted either way.
+	other := t.TempDir()
+	if _, err := certs.Init(certs.Request{Dir: other, Hosts: []string{"127.0.0.1"}, Clients: []string{"mallory"}, Valid: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(file(certs.CAFile), filepath.Join(other, certs.CertFile("mallory")), filepath.Join(other, certs.KeyFile("mallory"))); err == nil {
+		t.Error("with a certificate from another CA: want error")
+	}
+	if err := call(filepath.Join(other, certs.CAFile), file(certs.CertFile("alice")), file(certs.KeyFile("alice"))); err == nil {
+		t.Error("trusting another CA: want error")
+	}
+}
+
+func TestCerts_CAOutlivesLeaves(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := certs.Init(certs.Request{Dir: dir, Hosts: []string{"localhost"}, Valid: 30 * 24 * time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	ca, server := parseCert(t, filepath.Join(dir, certs.CAFile)), parseCert(t, filepath.Join(dir, certs.CertFile("server")))
+	if !ca.NotAfter.After(time.Now().AddDate(9, 0, 0)) {
+		t.Errorf("CA expires %v, want ten years", ca.NotAfter)
+	}
+	if server.NotAfter.After(time.Now().AddDate(0, 0, 31)) {
+		t.Errorf("server certificate expires %v, want 30 days", server.NotAfter)
+	}
+}
+
+func parseCert(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestCerts_InitReusesCA(t *testing.T) {
+	dir := t.TempDir()
+	req := certs.Request{Dir: dir, Hosts: []string{"localhost"}, Clients: []string{"alice"}, Valid: time.Hour}
+	if _, err := certs.Init(req); err != nil {
+		t.Fatal(err)
+	}
+
+	req.Clients = []string{"alice", "bob"}
+	written, err := certs.Init(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "bob.pem"), filepath.Join(dir, "bob-key.pem")}
+	if len(written) != 2 || written[0] != want[0] || written[1] != want[1] {
+		t.Errorf("second Init wrote %v, want only %v", written, want)
+	}
+
+	for _, name := range []string{"ca", "server-key", "../../x", "a/b", ".hidden", ""} {
+		if _, err := certs.Init(certs.Request{Dir: dir, Hosts: []string{"localhost"}, Clients: []string{name}, Valid: time.Hour}); err == nil {
+			t.Errorf("client named %q: want error", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "..", "..", "x.pem")); err == nil {
+		t.Error("Init wrote outside its directory")
+	}
+	if _, err := certs.Init(certs.Request{Dir: t.TempDir(), Valid: time.Hour}); err == nil {
+		t.Error("no hosts: want error")
+	}
+}
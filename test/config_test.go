@@ -0,0 +1,116 @@
+package test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/config"
+)
+
+func TestConfig_Layers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+server:
+  listen: 127.0.0.1:6000
+  renotify_interval: 2m
+  notifier_opts: {urgency: low}
+client:
+  server: remind.example.com:50051
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("REMINDME_RENOTIFY_INTERVAL", "10m")
+	t.Setenv("REMINDME_LOG_LEVEL", "debug")
+
+	cfg, src, err := config.LoadServer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Listen != "127.0.0.1:6000" || src["listen"] != "file" {
+		t.Errorf("listen = %q (%s), want file value", cfg.Listen, src["listen"])
+	}
+	if cfg.RenotifyInterval != 10*time.Minute || src["renotify_interval"] != "env" {
+		t.Errorf("renotify_interval = %v (%s), want env to override file", cfg.RenotifyInterval, src["renotify_interval"])
+	}
+	if cfg.LogLevel != "debug" || src["log_level"] != "env" {
+		t.Errorf("log_level = %q (%s)", cfg.LogLevel, src["log_level"])
+	}
+	if cfg.Store != "csv" || src["store"] != "default" {
+		t.Errorf("store = %q (%s), want default", cfg.Store, src["store"])
+	}
+	if cfg.NotifierOpts["urgency"] != "low" {
+		t.Errorf("notifier_opts = %v", cfg.NotifierOpts)
+	}
+
+	// Flags bound to the loaded values win over every other layer.
+	fs := flag.NewFlagSet("remindserver", flag.ContinueOnError)
+	fs.StringVar(&cfg.Listen, "listen", cfg.Listen, "")
+	if err := fs.Parse([]string{"-listen", ":7000"}); err != nil {
+		t.Fatal(err)
+	}
+	src.MarkFlags(fs)
+	if cfg.Listen != ":7000" || src["listen"] != "flag" {
+		t.Errorf("listen = %q (%s), want flag value", cfg.Listen, src["listen"])
+	}
+
+	client, csrc, err := config.LoadClient(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Server != "remind.example.com:50051" || csrc["server"] != "file" {
+		t.Errorf("client server = %q (%s)", client.Server, csrc["server"])
+	}
+}
+
+func TestConfig_SettingsHideSecrets(t *testing.T) {
+	cfg := config.DefaultServer()
+	cfg.NotifierOpts = map[string]string{"smtp_host": "mail.example.com", "smtp_password": "hunter2", "api_token": "rmd_x",
+		"webhook_urls": "https://hooks.example.com/remind", "webhook_secret": "s3cret"}
+	for _, set := range config.Settings(cfg, config.Sources{}) {
+		if set.Key != "notifier_opts" {
+			continue
+		}
+		want := "api_token=***,smtp_host=mail.example.com,smtp_password=***,webhook_secret=***,webhook_urls=https://hooks.example.com/remind"
+		if set.Value != want {
+			t.Errorf("notifier_opts = %q, want %q", set.Value, want)
+		}
+		return
+	}
+	t.Error("no notifier_opts setting")
+}
+
+func TestConfig_MissingFileAndUnknownKey(t *testing.T) {
+	dir := t.TempDir()
+	// No server socket to discover.
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	cfg, _, err := config.LoadClient(filepath.Join(dir, "none.yaml"))
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if got := cfg.Target(); got != "localhost:50051" {
+		t.Errorf("target = %q, want default", got)
+	}
+
+	path := filepath.Join(dir, "bad.yaml")
+	os.WriteFile(path, []byte("server:\n  listne: :1\n"), 0o600)
+	if _, _, err := config.LoadServer(path); err == nil {
+		t.Error("unknown key: want error")
+	}
+}
+
+func TestConfig_PathFromArgs(t *testing.T) {
+	if got := config.PathFromArgs([]string{"--config=/a.yaml", "list"}); got != "/a.yaml" {
+		t.Errorf("got %q", got)
+	}
+	if got := config.PathFromArgs([]string{"-server", "x:1", "-config", "/b.yaml"}); got != "/b.yaml" {
+		t.Errorf("got %q", got)
+	}
+	t.Setenv("REMINDME_CONFIG", "/c.yaml")
+	if got := config.PathFromArgs([]string{"list", "--config", "/d.yaml"}); got != "/c.yaml" {
+		t.Errorf("flags after the command: got %q, want default", got)
+	}
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startTestServer serves s over an in-memory listener and returns a client
+// connected to it.
+func startTestServer(t *testing.T, srv schedulepb.SchedulerServer, opts ...grpc.ServerOption) schedulepb.SchedulerClient {
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer(opts...)
+	schedulepb.RegisterSchedulerServer(g, srv)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return schedulepb.NewSchedulerClient(conn)
+}
+
+func TestWatchEvents(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	client := startTestServer(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchEvents(ctx, &schedulepb.WatchRequest{
+		Types: []schedulepb.EventType{schedulepb.EventType_EVENT_TYPE_ADDED, schedulepb.EventType_EVENT_TYPE_DELETED},
+	})
+	if err != nil {
+		t.Fatalf("WatchEvents failed: %v", err)
+	}
+	// The subscription is registered once the stream's headers arrive.
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("failed to read stream header: %v", err)
+	}
+
+	if _, err := client.AddSchedule(ctx, &schedulepb.ScheduleRequest{Title: "Watched", Datetime: "2999-01-01 09:00"}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	if _, err := client.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{}); err == nil {
+		t.Fatal("expected error updating without id")
+	}
+	if _, err := client.DeleteSchedule(ctx, &schedulepb.ScheduleIdx{Idx: 1}); err != nil {
+		t.Fatalf("DeleteSchedule failed: %v", err)
+	}
+
+	for _, want := range []schedulepb.EventType{schedulepb.EventType_EVENT_TYPE_ADDED, schedulepb.EventType_EVENT_TYPE_DELETED} {
+		ev, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		if ev.Type != want || ev.Schedule.Title != "Watched" {
+			t.Errorf("Expected %v for Watched, got %v", want, ev)
+		}
+	}
+}
+
+func TestWatchEvents_StartupMissed(t *testing.T) {
+	s, path, cleanup := createTempServer(t)
+	defer cleanup()
+
+	if err := os.WriteFile(path, []byte("past-id,Missed,2001-01-01 09:00,,\n"), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+	// Restore runs before the server serves, as it does in remindserver.
+	if _, err := s.Restore(watcher.MissedSkip); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	client := startTestServer(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for range 2 {
+		stream, err := client.WatchEvents(ctx, &schedulepb.WatchRequest{
+			Types: []schedulepb.EventType{schedulepb.EventType_EVENT_TYPE_MISSED},
+		})
+		if err != nil {
+			t.Fatalf("WatchEvents failed: %v", err)
+		}
+		ev, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		if ev.Type != schedulepb.EventType_EVENT_TYPE_MISSED || ev.Schedule.Id != "past-id" {
+			t.Errorf("Expected the startup missed event, got %v", ev)
+		}
+	}
+}
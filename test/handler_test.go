@@ -2,11 +2,20 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
 	"github.com/je0ng3/remindme-cli/internal/server"
+	"github.com/je0ng3/remindme-cli/internal/store"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func createTempServer(t *testing.T) (*server.ScheduleServer, string, func()) {
@@ -32,7 +41,7 @@ func TestAddSchedule_Success(t *testing.T) {
 
 	_, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
 		Title:    "Test Schedule",
-		Datetime: "2025-07-20 10:00",
+		Datetime: "2999-07-20 10:00",
 		Url:      "https://example.com",
 		Memo:     "Test memo",
 	})
@@ -64,14 +73,14 @@ func TestListSchedules(t *testing.T) {
 	// Add multiple schedules
 	s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
 		Title:    "Schedule One",
-		Datetime: "2025-07-20",
+		Datetime: "2999-07-20 09:00",
 	})
 	s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
 		Title:    "Schedule Two",
-		Datetime: "2025-07-21",
+		Datetime: "2999-07-21 09:00",
 	})
 
-	resp, err := s.ListSchedules(ctx, &schedulepb.Empty{})
+	resp, err := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
 	if err != nil {
 		t.Fatalf("ListSchedules failed: %v", err)
 	}
@@ -90,7 +99,7 @@ func TestDeleteSchedule_Success(t *testing.T) {
 	// Add one schedule
 	_, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
 		Title:    "Delete Me",
-		Datetime: "2025-07-22",
+		Datetime: "2999-07-22 09:00",
 	})
 	if err != nil {
 		t.Fatalf("AddSchedule failed: %v", err)
@@ -106,7 +115,7 @@ func TestDeleteSchedule_Success(t *testing.T) {
 	}
 
 	// Verify it's gone
-	listAfter, _ := s.ListSchedules(ctx, &schedulepb.Empty{})
+	listAfter, _ := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
 	if len(listAfter.Schedules) != 0 {
 		t.Errorf("Expected 0 schedules after deletion, got %d", len(listAfter.Schedules))
 	}
@@ -125,4 +134,235 @@ func TestDeleteSchedule_NotFound(t *testing.T) {
 	if resp.Message != "Invalid index" {
 		t.Errorf("Expected 'Invalid index', got '%s'", resp.Message)
 	}
-}
\ No newline at end of file
+}
+
+func TestRestore_SkipsMissed(t *testing.T) {
+	s, path, cleanup := createTempServer(t)
+	defer cleanup()
+
+	rows := "past-id,Missed,2001-01-01 09:00,,\nfuture-id,Upcoming,2999-01-01 09:00,,\n"
+	if err := os.WriteFile(path, []byte(rows), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+
+	n, err := s.Restore(watcher.MissedSkip)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 restored schedules, got %d", n)
+	}
+
+	resp, _ := s.ListSchedules(context.TODO(), &schedulepb.ListSchedulesRequest{})
+	if len(resp.Schedules) != 1 || resp.Schedules[0].Id != "future-id" {
+		t.Errorf("Expected only the future schedule to remain, got %v", resp.Schedules)
+	}
+}
+
+func TestRestore_AdvancesMissedRecurring(t *testing.T) {
+	s, path, cleanup := createTempServer(t)
+	defer cleanup()
+
+	rows := "weekly-id,Standup,2001-01-01 09:00,,,FREQ=WEEKLY\n"
+	if err := os.WriteFile(path, []byte(rows), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+
+	if _, err := s.Restore(watcher.MissedSkip); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	resp, _ := s.ListSchedules(context.TODO(), &schedulepb.ListSchedulesRequest{})
+	if len(resp.Schedules) != 1 {
+		t.Fatalf("Expected recurring schedule to be kept, got %d", len(resp.Schedules))
+	}
+	next, err := watcher.ParseDatetime(resp.Schedules[0].Datetime)
+	if err != nil {
+		t.Fatalf("invalid next datetime: %v", err)
+	}
+	if !next.After(time.Now()) || next.Weekday() != time.Monday {
+		t.Errorf("Expected next Monday occurrence, got %s", resp.Schedules[0].Datetime)
+	}
+}
+
+func TestUpdateSchedule_Partial(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+
+	ctx := context.TODO()
+
+	s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
+		Title:    "Tpyo",
+		Datetime: "2999-01-01 09:00",
+		Memo:     "keep me",
+	})
+	list, _ := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+	id := list.Schedules[0].Id
+
+	_, err := s.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{
+		Id:         id,
+		Schedule:   &schedulepb.ScheduleRequest{Title: "Typo", Url: "https://ignored.example"},
+		UpdateMask: []string{"title"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSchedule failed: %v", err)
+	}
+
+	list, _ = s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+	got := list.Schedules[0]
+	if got.Title != "Typo" || got.Memo != "keep me" || got.Url != "" {
+		t.Errorf("Unexpected schedule after update: %v", got)
+	}
+
+	_, err = s.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{
+		Id:       "missing",
+		Schedule: &schedulepb.ScheduleRequest{Title: "x"},
+	})
+	if err == nil {
+		t.Error("expected error for unknown id, got nil")
+	}
+}
+
+func TestSchedule_ServerOwnedState(t *testing.T) {
+	s, path, cleanup := createTempServer(t)
+	defer cleanup()
+	ctx := context.TODO()
+
+	// A one-off that claims to be waiting for an acknowledgement would never
+	// be armed after a restart.
+	_, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{Title: "Forged", Datetime: "2999-01-01 09:00",
+		State: "awaiting_ack", AckDue: "2999-01-01T09:05:00Z", NotifyCount: 7, FiredAlerts: []string{"0"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, _ := store.NewCSV(path).List()
+	if got := list[0]; got.State != "" || got.AckDue != "" || got.NotifyCount != 0 || len(got.FiredAlerts) != 0 {
+		t.Errorf("stored state = %q, %q, %d, %v; want none", got.State, got.AckDue, got.NotifyCount, got.FiredAlerts)
+	}
+
+	for _, field := range []string{"state", "ack_due", "notify_count", "fired_alerts", "next_alert"} {
+		_, err := s.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{
+			Id:         list[0].Id,
+			Schedule:   &schedulepb.ScheduleRequest{State: "awaiting_ack", NotifyCount: 7},
+			UpdateMask: []string{field},
+		})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "kept by the server") {
+			t.Errorf("update_mask %s: got %v, want InvalidArgument", field, err)
+		}
+	}
+}
+
+func TestUpdateSchedule_Concurrent(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+
+	ctx := context.TODO()
+
+	const n = 20
+	for i := range n {
+		s.AddSchedule(ctx, &schedulepb.ScheduleRequest{Title: fmt.Sprint(i), Datetime: "2999-01-01 09:00"})
+	}
+	list, _ := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+
+	// Two writers change different fields of every schedule at once;
+	// neither may undo the other's change.
+	var wg sync.WaitGroup
+	for _, sch := range list.Schedules {
+		for _, field := range []string{"title", "memo"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{
+					Id:         sch.Id,
+					Schedule:   &schedulepb.ScheduleRequest{Title: "new", Memo: "new"},
+					UpdateMask: []string{field},
+				})
+				if err != nil {
+					t.Errorf("UpdateSchedule %s: %v", field, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	list, _ = s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+	for _, sch := range list.Schedules {
+		if sch.Title != "new" || sch.Memo != "new" {
+			t.Errorf("Lost update: title %q, memo %q", sch.Title, sch.Memo)
+		}
+	}
+}
+
+func TestGetSchedule_ByPrefix(t *testing.T) {
+	s, path, cleanup := createTempServer(t)
+	defer cleanup()
+
+	rows := "abcd1111-0000,One,2999-01-01 09:00,,\nabcd2222-0000,Two,2999-01-01 10:00,,\n"
+	if err := os.WriteFile(path, []byte(rows), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+	ctx := context.TODO()
+
+	got, err := s.GetSchedule(ctx, &schedulepb.ScheduleId{Id: "abcd2"})
+	if err != nil {
+		t.Fatalf("GetSchedule failed: %v", err)
+	}
+	if got.Title != "Two" {
+		t.Errorf("Expected Two, got %s", got.Title)
+	}
+
+	if _, err := s.GetSchedule(ctx, &schedulepb.ScheduleId{Id: "abcd"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected ambiguous prefix error, got %v", err)
+	}
+	if _, err := s.GetSchedule(ctx, &schedulepb.ScheduleId{Id: "ffff"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	if _, err := s.DeleteScheduleById(ctx, &schedulepb.ScheduleId{Id: "abcd1111-0000"}); err != nil {
+		t.Fatalf("DeleteScheduleById failed: %v", err)
+	}
+	list, _ := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+	if len(list.Schedules) != 1 || list.Schedules[0].Title != "Two" {
+		t.Errorf("Expected only Two to remain, got %v", list.Schedules)
+	}
+}
+
+func TestAddSchedule_FieldViolations(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+
+	_, err := s.AddSchedule(context.TODO(), &schedulepb.ScheduleRequest{
+		Title:    "Broken",
+		Datetime: "2001-01-01 09:00",
+		Url:      "javascript:alert(1)",
+		Rrule:    "FREQ=HOURLY",
+	})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.FieldViolations {
+				fields[fv.Field] = true
+			}
+		}
+	}
+	for _, f := range []string{"datetime", "url", "rrule"} {
+		if !fields[f] {
+			t.Errorf("Expected a violation for %s, got %v", f, fields)
+		}
+	}
+
+	_, err = s.AddSchedule(context.TODO(), &schedulepb.ScheduleRequest{Title: "No time", Datetime: "soon"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unparsable datetime, got %v", err)
+	}
+
+	list, _ := s.ListSchedules(context.TODO(), &schedulepb.ListSchedulesRequest{})
+	if len(list.Schedules) != 0 {
+		t.Errorf("Expected nothing to be stored, got %d schedules", len(list.Schedules))
+	}
+}
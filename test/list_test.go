@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListSchedules_FilterSortPage(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	ctx := context.TODO()
+
+	for _, sch := range []*schedulepb.ScheduleRequest{
+		{Title: "Standup", Datetime: "2999-01-03T09:00:00Z", Tz: "UTC", Tags: []string{"work"}},
+		{Title: "Dentist", Datetime: "2999-01-01T09:00:00Z", Tz: "UTC", Memo: "bring insurance card"},
+		{Title: "Retro", Datetime: "2999-01-02T09:00:00Z", Tz: "UTC", Tags: []string{"work", "team"}},
+		{Title: "Payday", Datetime: "2999-02-01T09:00:00Z", Tz: "UTC"},
+	} {
+		if _, err := s.AddSchedule(ctx, sch); err != nil {
+			t.Fatalf("AddSchedule failed: %v", err)
+		}
+	}
+
+	titles := func(req *schedulepb.ListSchedulesRequest) ([]string, string) {
+		t.Helper()
+		res, err := s.ListSchedules(ctx, req)
+		if err != nil {
+			t.Fatalf("ListSchedules(%v) failed: %v", req, err)
+		}
+		var out []string
+		for _, sch := range res.Schedules {
+			out = append(out, sch.Title)
+		}
+		return out, res.NextPageToken
+	}
+	expect := func(got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Expected %v, got %v", want, got)
+			}
+		}
+	}
+
+	got, _ := titles(&schedulepb.ListSchedulesRequest{})
+	expect(got, "Standup", "Dentist", "Retro", "Payday")
+
+	got, _ = titles(&schedulepb.ListSchedulesRequest{Sort: "datetime"})
+	expect(got, "Dentist", "Retro", "Standup", "Payday")
+
+	got, _ = titles(&schedulepb.ListSchedulesRequest{Sort: "-title"})
+	expect(got, "Standup", "Retro", "Payday", "Dentist")
+
+	got, _ = titles(&schedulepb.ListSchedulesRequest{From: "2999-01-02T00:00:00Z", To: "2999-02-01T09:00:00Z"})
+	expect(got, "Standup", "Retro")
+
+	got, _ = titles(&schedulepb.ListSchedulesRequest{Query: "INSURANCE"})
+	expect(got, "Dentist")
+
+	got, _ = titles(&schedulepb.ListSchedulesRequest{Regex: "^(Re|Pa)"})
+	expect(got, "Retro", "Payday")
+
+	got, _ = titles(&schedulepb.ListSchedulesRequest{Tags: []string{"work", "TEAM"}})
+	expect(got, "Retro")
+
+	got, token := titles(&schedulepb.ListSchedulesRequest{Sort: "datetime", PageSize: 3})
+	expect(got, "Dentist", "Retro", "Standup")
+	if token == "" {
+		t.Fatal("Expected a next page token")
+	}
+	got, token = titles(&schedulepb.ListSchedulesRequest{Sort: "datetime", PageSize: 3, PageToken: token})
+	expect(got, "Payday")
+	if token != "" {
+		t.Errorf("Expected no token after the last page, got %q", token)
+	}
+
+	// Plain pages in insertion order are cut by the store.
+	got, token = titles(&schedulepb.ListSchedulesRequest{PageSize: 3})
+	expect(got, "Standup", "Dentist", "Retro")
+	got, token = titles(&schedulepb.ListSchedulesRequest{PageSize: 3, PageToken: token})
+	expect(got, "Payday")
+	if token != "" {
+		t.Errorf("Expected no token after the last page, got %q", token)
+	}
+	got, token = titles(&schedulepb.ListSchedulesRequest{From: "2999-01-02T00:00:00Z", PageSize: 1})
+	expect(got, "Standup")
+	got, _ = titles(&schedulepb.ListSchedulesRequest{From: "2999-01-02T00:00:00Z", PageSize: 1, PageToken: token})
+	expect(got, "Retro")
+
+	for _, req := range []*schedulepb.ListSchedulesRequest{
+		{Regex: "("},
+		{Sort: "color"},
+		{PageToken: "garbage"},
+		{From: "yesterday"},
+	} {
+		if _, err := s.ListSchedules(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/notify"
+)
+
+func TestNotifyRegistry(t *testing.T) {
+	for _, name := range []string{"terminal-notifier", "freedesktop"} {
+		if _, err := notify.New(name, nil); err != nil {
+			t.Errorf("New(%q) failed: %v", name, err)
+		}
+	}
+
+	if _, err := notify.New("carrier-pigeon", nil); err == nil {
+		t.Error("expected error for unknown backend, got nil")
+	}
+	if _, err := notify.New("freedesktop", notify.Options{"urgency": "panic"}); err == nil {
+		t.Error("expected error for invalid urgency, got nil")
+	}
+}
+
+// fakeCommand puts an executable name on PATH that writes its arguments,
+// each ended by a NUL byte, to the returned file. readArgs waits for the file.
+func fakeCommand(t *testing.T, name string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a shell script")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "args")
+	script := "#!/bin/sh\nprintf '%s\\0' \"$@\" > " + out + ".tmp && mv " + out + ".tmp " + out + "\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return out
+}
+
+func readArgs(t *testing.T, path string) []string {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if data, err := os.ReadFile(path); err == nil {
+			os.Remove(path)
+			return strings.Split(strings.TrimSuffix(string(data), "\x00"), "\x00")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was not written", path)
+	return nil
+}
+
+func TestFreedesktop_Args(t *testing.T) {
+	out := fakeCommand(t, "notify-send")
+	n, err := notify.New("freedesktop", notify.Options{"urgency": "critical"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Titles and memos that look like options stay positional.
+	if err := n.Notify(notify.Notification{Title: "-u low --help", Memo: "--icon=x"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--app-name=remindme", "--urgency=critical", "--", "-u low --help", "--icon=x"}
+	if got := readArgs(t, out); !slices.Equal(got, want) {
+		t.Errorf("notify-send args = %q, want %q", got, want)
+	}
+
+	if err := n.Notify(notify.Notification{Title: "-회의", Memo: "3층", URL: "https://example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"--app-name=remindme", "--urgency=critical", "--action=open=열기", "--wait", "--", "-회의", "3층\nhttps://example.com"}
+	if got := readArgs(t, out); !slices.Equal(got, want) {
+		t.Errorf("notify-send args with a URL = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/recur"
+)
+
+func mustParseRule(t *testing.T, s string) *recur.Rule {
+	t.Helper()
+	r, err := recur.Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", s, err)
+	}
+	return r
+}
+
+func TestRecur_WeeklyByDay(t *testing.T) {
+	r := mustParseRule(t, "FREQ=WEEKLY;BYDAY=MO,WE")
+	start := time.Date(2025, 7, 21, 9, 0, 0, 0, time.UTC) // Monday
+
+	got := r.Between(start, start, start.AddDate(0, 0, 14))
+	want := []time.Time{
+		start,
+		start.AddDate(0, 0, 2),
+		start.AddDate(0, 0, 7),
+		start.AddDate(0, 0, 9),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d occurrences, got %v", len(want), got)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRecur_MonthlySkipsMissingDays(t *testing.T) {
+	r := mustParseRule(t, "FREQ=MONTHLY")
+	start := time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)
+
+	next, _, ok := r.Advance(start, start)
+	if !ok {
+		t.Fatal("Expected a next occurrence")
+	}
+	if want := time.Date(2025, 3, 31, 8, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, next)
+	}
+}
+
+func TestRecur_CountIsConsumed(t *testing.T) {
+	r := mustParseRule(t, "daily;COUNT=3")
+	start := time.Date(2025, 7, 1, 7, 30, 0, 0, time.UTC)
+
+	next, rest, ok := r.Advance(start, start.Add(25*time.Hour))
+	if !ok {
+		t.Fatal("Expected a next occurrence")
+	}
+	if want := start.AddDate(0, 0, 2); !next.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, next)
+	}
+	if rest.String() != "FREQ=DAILY;COUNT=1" {
+		t.Errorf("Unexpected remaining rule %s", rest)
+	}
+	if _, _, ok := rest.Advance(next, next); ok {
+		t.Error("Expected the series to end")
+	}
+}
+
+func TestRecur_RepeatedDaysCountOnce(t *testing.T) {
+	utc := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 9, 0, 0, 0, time.UTC) }
+	for _, c := range []struct {
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		// 31 and -1 are the same day in a 31-day month.
+		{"FREQ=MONTHLY;BYMONTHDAY=31,-1;COUNT=4", utc(1, 31), []time.Time{utc(1, 31), utc(2, 28), utc(3, 31), utc(4, 30)}},
+		{"FREQ=WEEKLY;BYDAY=MO,MO;COUNT=3", utc(1, 6), []time.Time{utc(1, 6), utc(1, 13), utc(1, 20)}},
+	} {
+		r := mustParseRule(t, c.rule)
+		got := r.Between(c.start, c.start, c.start.AddDate(1, 0, 0))
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %v, want %v", c.rule, got, c.want)
+			continue
+		}
+		for i := range c.want {
+			if !got[i].Equal(c.want[i]) {
+				t.Errorf("%s: occurrence %d = %v, want %v", c.rule, i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestRecur_Until(t *testing.T) {
+	r := mustParseRule(t, "FREQ=YEARLY;UNTIL=20270101")
+	start := time.Date(2025, 12, 25, 10, 0, 0, 0, time.Local)
+
+	got := r.Between(start, start, start.AddDate(10, 0, 0))
+	if len(got) != 2 {
+		t.Errorf("Expected 2 occurrences before UNTIL, got %v", got)
+	}
+}
+
+func TestRecur_ParseErrors(t *testing.T) {
+	for _, s := range []string{"", "FREQ=HOURLY", "FREQ=DAILY;BYDAY=MO", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;COUNT=2;UNTIL=20250101"} {
+		if _, err := recur.Parse(s); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", s)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+)
+
+func TestScheduler_FiresInOrder(t *testing.T) {
+	fired := make(chan string, 3)
+	s := watcher.NewScheduler(func(id string) { fired <- id })
+	defer s.Stop()
+
+	now := time.Now()
+	s.Add("c", now.Add(60*time.Millisecond))
+	s.Add("a", now.Add(20*time.Millisecond))
+	s.Add("b", now.Add(40*time.Millisecond))
+
+	for _, want := range []string{"a", "b", "c"} {
+		select {
+		case got := <-fired:
+			if got != want {
+				t.Fatalf("Expected %s to fire, got %s", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %s", want)
+		}
+	}
+	// Fired entries are dropped.
+	for _, id := range []string{"a", "b", "c"} {
+		if s.Remove(id) {
+			t.Errorf("Expected %s to be gone after firing", id)
+		}
+	}
+}
+
+func TestScheduler_RemoveAndReschedule(t *testing.T) {
+	fired := make(chan string, 3)
+	s := watcher.NewScheduler(func(id string) { fired <- id })
+	defer s.Stop()
+
+	now := time.Now()
+	s.Add("removed", now.Add(20*time.Millisecond))
+	s.Add("moved", now.Add(time.Hour))
+
+	if !s.Remove("removed") {
+		t.Fatal("Expected Remove to find the entry")
+	}
+	if s.Remove("removed") {
+		t.Error("Expected second Remove to report false")
+	}
+	if !s.Reschedule("moved", now.Add(30*time.Millisecond)) {
+		t.Fatal("Expected Reschedule to find the entry")
+	}
+
+	select {
+	case got := <-fired:
+		if got != "moved" {
+			t.Fatalf("Expected moved to fire, got %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for rescheduled entry")
+	}
+
+	select {
+	case got := <-fired:
+		t.Errorf("Unexpected fire of %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
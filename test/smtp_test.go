@@ -0,0 +1,187 @@
+package test
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/notify"
+)
+
+// smtpMessage is one mail the fake server accepted.
+type smtpMessage struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP runs a minimal SMTP server that offers AUTH PLAIN but not
+// STARTTLS, and returns its port and the mails it accepts.
+func fakeSMTP(t *testing.T) (string, chan smtpMessage) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	got := make(chan smtpMessage, 10)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, got)
+		}
+	}()
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+	return port, got
+}
+
+func serveSMTP(conn net.Conn, got chan smtpMessage) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+	var msg smtpMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			tp.PrintfLine("250-fake\r\n250 AUTH PLAIN")
+		case "AUTH":
+			_, resp, _ := strings.Cut(arg, " ")
+			creds, _ := base64.StdEncoding.DecodeString(resp)
+			msg.auth = string(creds)
+			tp.PrintfLine("235 ok")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, _ := io.ReadAll(tp.DotReader())
+			msg.data = string(data)
+			tp.PrintfLine("250 queued")
+			got <- msg
+			msg = smtpMessage{}
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func waitMail(t *testing.T, got chan smtpMessage) smtpMessage {
+	t.Helper()
+	select {
+	case m := <-got:
+		return m
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a mail")
+		return smtpMessage{}
+	}
+}
+
+func TestSMTP_SendsTextAndHTML(t *testing.T) {
+	port, got := fakeSMTP(t)
+	n, err := notify.New("smtp", notify.Options{
+		"smtp_host": "127.0.0.1", "smtp_port": port, "smtp_starttls": "false",
+		"smtp_username": "bot", "smtp_password": "pw",
+		"smtp_from": "Remindme <bot@example.com>", "smtp_to": "team@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := n.Notify(notify.Notification{ID: "id-1", Title: "배포 점검", Memo: "1 < 2\nroom 3", URL: "https://example.com/?a=1&b=2"}); err != nil {
+		t.Fatal(err)
+	}
+	m := waitMail(t, got)
+	if m.auth != "\x00bot\x00pw" {
+		t.Errorf("auth = %q", m.auth)
+	}
+	if m.from != "bot@example.com" || len(m.to) != 1 || m.to[0] != "team@example.com" {
+		t.Errorf("envelope %s -> %v", m.from, m.to)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(m.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != "배포 점검" {
+		t.Errorf("subject = %q", subject)
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := map[string]string{}
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		typ, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(p)
+		parts[typ] = string(body)
+	}
+	if text := parts["text/plain"]; !strings.Contains(text, "1 < 2\nroom 3") || !strings.Contains(text, "https://example.com/?a=1&b=2") {
+		t.Errorf("text part = %q", text)
+	}
+	if page := parts["text/html"]; !strings.Contains(page, `<a href="https://example.com/?a=1&amp;b=2">`) || !strings.Contains(page, "1 &lt; 2") {
+		t.Errorf("html part = %q", page)
+	}
+
+	// A schedule's own recipients replace the default ones.
+	n.Notify(notify.Notification{ID: "id-2", Title: "Standup", To: []string{"alice@example.com", "bob@example.com"}})
+	if m := waitMail(t, got); len(m.to) != 2 || m.to[0] != "alice@example.com" {
+		t.Errorf("recipients = %v, want the schedule's", m.to)
+	}
+}
+
+func TestSMTP_RequiresStartTLS(t *testing.T) {
+	port, got := fakeSMTP(t)
+	n, err := notify.New("smtp", notify.Options{"smtp_host": "127.0.0.1", "smtp_port": port, "smtp_from": "bot@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Notify(notify.Notification{ID: "id-1", Title: "Standup"}); err == nil {
+		t.Error("no recipients: expected error")
+	}
+	n.Notify(notify.Notification{ID: "id-1", Title: "Standup", To: []string{"alice@example.com"}})
+	select {
+	case <-got:
+		t.Error("mail sent although the server does not offer STARTTLS")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestSMTP_Options(t *testing.T) {
+	for _, opts := range []notify.Options{
+		{"smtp_from": "bot@example.com"},
+		{"smtp_host": "mail.example.com"},
+		{"smtp_host": "mail.example.com", "smtp_from": "bot@example.com", "smtp_port": "smtp"},
+		{"smtp_host": "mail.example.com", "smtp_from": "bot@example.com", "smtp_starttls": "maybe"},
+		{"smtp_host": "mail.example.com", "smtp_from": "bot@example.com", "smtp_to": "not an address"},
+	} {
+		if _, err := notify.New("smtp", opts); err == nil {
+			t.Errorf("New(smtp, %v): expected error", opts)
+		}
+	}
+}
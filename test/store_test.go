@@ -0,0 +1,267 @@
+package test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/store"
+)
+
+func openStores(t *testing.T) map[string]store.Store {
+	dir := t.TempDir()
+	stores := map[string]store.Store{}
+	for kind, name := range map[string]string{"csv": "schedules.csv", "sqlite": "schedules.db"} {
+		st, err := store.Open(kind, filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to open %s store: %v", kind, err)
+		}
+		t.Cleanup(func() { st.Close() })
+		stores[kind] = st
+	}
+	return stores
+}
+
+func TestStore_CRUD(t *testing.T) {
+	for kind, st := range openStores(t) {
+		t.Run(kind, func(t *testing.T) {
+			for _, sch := range []*schedulepb.ScheduleRequest{
+				{Id: "aaaa-1", Title: "First", Datetime: "2999-01-02 09:00"},
+				{Id: "aaab-2", Title: "Second", Datetime: "2999-01-01 09:00", Memo: "a, \"quoted\"\nmemo"},
+				{Id: "bbbb-3", Title: "Third", Datetime: "2999-01-03 09:00", Rrule: "FREQ=DAILY", Tags: []string{"work", "team"}, Owner: "alice",
+					Emails: []string{"alice@example.com"}},
+			} {
+				if err := st.Add(sch); err != nil {
+					t.Fatalf("Add failed: %v", err)
+				}
+			}
+
+			list, err := st.List()
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(list) != 3 || list[0].Title != "First" || list[2].Title != "Third" {
+				t.Fatalf("Expected insertion order, got %v", list)
+			}
+			if list[1].Memo != "a, \"quoted\"\nmemo" {
+				t.Errorf("Memo not preserved: %q", list[1].Memo)
+			}
+
+			matches, _ := st.FindPrefix("aaa")
+			if len(matches) != 2 {
+				t.Errorf("Expected 2 prefix matches, got %d", len(matches))
+			}
+
+			got, err := st.Get("bbbb-3")
+			if err != nil || got.Rrule != "FREQ=DAILY" {
+				t.Fatalf("Get failed: %v %v", got, err)
+			}
+			got.Title = "Renamed"
+			if err := st.Update(got); err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if got, _ := st.Get("bbbb-3"); got.Title != "Renamed" {
+				t.Errorf("Expected updated title, got %s", got.Title)
+			}
+
+			if err := st.Delete("aaaa-1"); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			if _, err := st.Get("aaaa-1"); !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound after delete, got %v", err)
+			}
+			if err := st.Delete("aaaa-1"); !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+			}
+			if err := st.Update(&schedulepb.ScheduleRequest{Id: "nope"}); !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound updating missing id, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCSVStore_CheckQuarantinesMalformedRows(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schedules.csv")
+	rows := "good-1,One,2999-01-01 09:00,,\n" +
+		"short,Broken\n" +
+		"bad-quote,Bro\"ken,2999-01-01 09:00,,\n" +
+		"good-2,Two,2999-01-02 09:00,,,FREQ=DAILY\n"
+	if err := os.WriteFile(path, []byte(rows), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+
+	st := store.NewCSV(path)
+	list, err := st.List()
+	if err != nil {
+		t.Fatalf("List should skip malformed rows, got error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 usable rows, got %d", len(list))
+	}
+
+	n, err := st.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 quarantined rows, got %d", n)
+	}
+
+	quarantined, err := os.ReadFile(path + ".quarantine")
+	if err != nil {
+		t.Fatalf("failed to read quarantine file: %v", err)
+	}
+	if !strings.Contains(string(quarantined), "short,Broken") || !strings.Contains(string(quarantined), "bad-quote") {
+		t.Errorf("Unexpected quarantine content: %q", quarantined)
+	}
+
+	if n, _ := st.Check(); n != 0 {
+		t.Errorf("Expected clean file after Check, got %d bad rows", n)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Expected only the csv and quarantine files, got %v", entries)
+	}
+}
+
+func TestCSVStore_WriteQuarantinesRowsBrokenAfterCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.csv")
+	st := store.NewCSV(path)
+	if n, err := st.Check(); err != nil || n != 0 {
+		t.Fatalf("Check = %d, %v", n, err)
+	}
+	if err := st.Add(&schedulepb.ScheduleRequest{Id: "good-1", Title: "One", Datetime: "2999-01-01T09:00:00Z"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another program breaks a row while the server runs.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("edited,Half\n")
+	f.Close()
+
+	if err := st.Add(&schedulepb.ScheduleRequest{Id: "good-2", Title: "Two", Datetime: "2999-01-02T09:00:00Z"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Delete("good-1"); err != nil {
+		t.Fatal(err)
+	}
+	quarantined, err := os.ReadFile(path + ".quarantine")
+	if err != nil || string(quarantined) != "edited,Half\n" {
+		t.Errorf("quarantine = %q, %v; want the broken row once", quarantined, err)
+	}
+	if list, _ := st.List(); len(list) != 1 || list[0].Id != "good-2" {
+		t.Errorf("List = %v, want good-2", list)
+	}
+}
+
+func TestSQLiteStore_DueColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.db")
+
+	// A database from before the due column.
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE schedules (seq INTEGER PRIMARY KEY AUTOINCREMENT, id TEXT NOT NULL UNIQUE, title TEXT NOT NULL,
+		datetime TEXT NOT NULL, url TEXT NOT NULL DEFAULT '', memo TEXT NOT NULL DEFAULT '', rrule TEXT NOT NULL DEFAULT '');
+		INSERT INTO schedules (id, title, datetime) VALUES ('old', 'Old', '2030-01-01T08:30:00Z');`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := store.OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	defer st.Close()
+	// As text, 09:00+09:00 sorts after 08:30Z, but it is 00:00 UTC.
+	for _, sch := range []*schedulepb.ScheduleRequest{
+		{Id: "seoul", Title: "Seoul", Datetime: "2030-01-01T09:00:00+09:00"},
+		{Id: "later", Title: "Later", Datetime: "2030-01-01T10:00:00Z"},
+		{Id: "broken", Title: "Broken", Datetime: "someday"},
+	} {
+		if err := st.Add(sch); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	due := func(to time.Time) string {
+		t.Helper()
+		list, err := st.Query(store.Query{To: to})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		var ids []string
+		for _, sch := range list {
+			ids = append(ids, sch.Id)
+		}
+		return strings.Join(ids, ",")
+	}
+	if got := due(time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)); got != "old,seoul" {
+		t.Errorf("due before 09:00Z = %s, want old,seoul", got)
+	}
+
+	later, _ := st.Get("later")
+	later.Datetime = "2030-01-01T08:00:00Z"
+	st.Update(later)
+	if got := due(time.Date(2030, 1, 1, 8, 30, 0, 0, time.UTC)); got != "seoul,later" {
+		t.Errorf("due before 08:30Z after moving later earlier = %s, want seoul,later", got)
+	}
+}
+
+func TestStore_Query(t *testing.T) {
+	for kind, st := range openStores(t) {
+		t.Run(kind, func(t *testing.T) {
+			for i, datetime := range []string{
+				"2030-01-01T09:00:00+09:00", // 00:00 UTC
+				"2030-01-01T08:30:00Z",
+				"someday",
+				"2030-01-01T10:00:00Z",
+				"2030-01-02T00:00:00Z",
+			} {
+				if err := st.Add(&schedulepb.ScheduleRequest{Id: fmt.Sprint(i), Title: datetime, Datetime: datetime}); err != nil {
+					t.Fatalf("Add failed: %v", err)
+				}
+			}
+			jan1 := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			for _, c := range []struct {
+				q    store.Query
+				want string
+			}{
+				{store.Query{}, "0,1,2,3,4"},
+				{store.Query{From: jan1, To: jan1.AddDate(0, 0, 1)}, "0,1,3"},
+				{store.Query{From: jan1.Add(time.Hour)}, "1,3,4"},
+				{store.Query{To: jan1.Add(9 * time.Hour)}, "0,1"},
+				{store.Query{Limit: 2}, "0,1"},
+				{store.Query{Offset: 2, Limit: 2}, "2,3"},
+				{store.Query{Offset: 4}, "4"},
+				{store.Query{Offset: 9}, ""},
+				{store.Query{From: jan1, Offset: 1, Limit: 2}, "1,3"},
+			} {
+				list, err := st.Query(c.q)
+				if err != nil {
+					t.Fatalf("Query(%+v) failed: %v", c.q, err)
+				}
+				var ids []string
+				for _, sch := range list {
+					ids = append(ids, sch.Id)
+				}
+				if got := strings.Join(ids, ","); got != c.want {
+					t.Errorf("Query(%+v) = %s, want %s", c.q, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/auth"
+	"github.com/je0ng3/remindme-cli/internal/server"
+	"github.com/je0ng3/remindme-cli/internal/store"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var teamGroups = map[string][]string{"ops": {"bob", "carol"}}
+
+func TestTeam_AssigneesAreValidated(t *testing.T) {
+	s := server.NewServer(store.NewCSV(filepath.Join(t.TempDir(), "schedules.csv")), server.WithGroups(teamGroups))
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	_, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{Title: "Deploy", Datetime: "2999-01-01 09:00", Assignees: []string{"@nobody"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown group: got %v, want InvalidArgument", err)
+	}
+	_, err = s.AddSchedule(ctx, &schedulepb.ScheduleRequest{Title: "Deploy", Datetime: "2999-01-01 09:00", Assignees: []string{" dave ", "@ops", "dave"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.ListSchedules(auth.WithUser(context.Background(), "carol"), &schedulepb.ListSchedulesRequest{Team: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Schedules) != 1 {
+		t.Fatalf("carol's team list has %d schedules, want 1", len(res.Schedules))
+	}
+	sch := res.Schedules[0]
+	if !slices.Equal(sch.Assignees, []string{"dave", "@ops"}) || !slices.Equal(sch.Members, []string{"dave", "bob", "carol"}) {
+		t.Errorf("assignees %v, members %v", sch.Assignees, sch.Members)
+	}
+	if sch.Owner != "alice" {
+		t.Errorf("owner = %q, want alice", sch.Owner)
+	}
+
+	// Erin is neither the owner nor a member.
+	res, _ = s.ListSchedules(auth.WithUser(context.Background(), "erin"), &schedulepb.ListSchedulesRequest{})
+	if len(res.Schedules) != 0 {
+		t.Errorf("erin sees %d schedules, want none", len(res.Schedules))
+	}
+}
+
+func TestTeam_AckedByIsSetOnlyByAck(t *testing.T) {
+	st := store.NewCSV(filepath.Join(t.TempDir(), "schedules.csv"))
+	s := server.NewServer(st, server.WithGroups(teamGroups))
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	_, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{Title: "Deploy", Datetime: "2999-01-01 09:00",
+		Assignees: []string{"@ops"}, AckedBy: []string{"bob", "carol"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, _ := st.List()
+	if len(list[0].AckedBy) != 0 {
+		t.Errorf("acked_by = %v after add, want nobody", list[0].AckedBy)
+	}
+	for _, field := range []string{"acked_by", "members", "owner"} {
+		_, err := s.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{
+			Id:         list[0].Id,
+			Schedule:   &schedulepb.ScheduleRequest{AckedBy: []string{"bob"}, Owner: "bob"},
+			UpdateMask: []string{field},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("update_mask %s: got %v, want InvalidArgument", field, err)
+		}
+	}
+}
+
+// firedTeamServer returns a server whose only schedule, "team-id", belongs
+// to alice, is assigned to @ops and fires as soon as it is restored.
+func firedTeamServer(t *testing.T) (*server.ScheduleServer, store.Store) {
+	path := filepath.Join(t.TempDir(), "schedules.csv")
+	row := "team-id,Standup,2001-01-01 09:00,,,,,,,,,,,alice,@ops,\n"
+	if err := os.WriteFile(path, []byte(row), 0644); err != nil {
+		t.Fatalf("failed to seed csv: %v", err)
+	}
+	st := store.NewCSV(path)
+	notes := make(recordingNotifier, 10)
+	s := server.NewServer(st, server.WithNotifier(notes), server.WithRenotify(time.Hour, 3), server.WithGroups(teamGroups))
+	if _, err := s.Restore(watcher.MissedFire); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	waitNotification(t, notes)
+	return s, st
+}
+
+func TestTeam_AckedByEveryMember(t *testing.T) {
+	s, st := firedTeamServer(t)
+	bob := auth.WithUser(context.Background(), "bob")
+	carol := auth.WithUser(context.Background(), "carol")
+
+	if _, err := s.DeleteScheduleById(bob, &schedulepb.ScheduleId{Id: "team-id"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member deleting: got %v, want PermissionDenied", err)
+	}
+
+	if _, err := s.AckSchedule(bob, &schedulepb.ScheduleId{Id: "team-id"}); err != nil {
+		t.Fatalf("bob's ack failed: %v", err)
+	}
+	sch, err := st.Get("team-id")
+	if err != nil {
+		t.Fatalf("reminder closed before carol acknowledged: %v", err)
+	}
+	if sch.State != server.StateAwaitingAck || !slices.Equal(sch.AckedBy, []string{"bob"}) {
+		t.Errorf("state %q, acked by %v; want awaiting_ack by bob", sch.State, sch.AckedBy)
+	}
+
+	if _, err := s.AckSchedule(carol, &schedulepb.ScheduleId{Id: "team-id"}); err != nil {
+		t.Fatalf("carol's ack failed: %v", err)
+	}
+	waitGone(t, st, "team-id")
+}
+
+func TestTeam_OwnerClosesForEveryone(t *testing.T) {
+	s, st := firedTeamServer(t)
+	if _, err := s.AckSchedule(auth.WithUser(context.Background(), "alice"), &schedulepb.ScheduleId{Id: "team-id"}); err != nil {
+		t.Fatalf("owner's ack failed: %v", err)
+	}
+	waitGone(t, st, "team-id")
+}
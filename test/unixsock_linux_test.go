@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// dialAs connects to the socket at path as uid. Only the effective uid of
+// the calling thread is changed, so the rest of the test process keeps
+// running as root.
+func dialAs(t *testing.T, uid int, path string) (net.Conn, error) {
+	runtime.LockOSThread()
+	none := ^uintptr(0)
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SETRESUID, none, uintptr(uid), none); errno != 0 {
+		runtime.UnlockOSThread()
+		t.Fatalf("setresuid: %v", errno)
+	}
+	conn, err := net.Dial("unix", path)
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SETRESUID, none, 0, none); errno != 0 {
+		// Leave the thread locked so the runtime discards it.
+		t.Fatalf("restoring euid: %v", errno)
+	}
+	runtime.UnlockOSThread()
+	return conn, err
+}
+
+// clientOver returns a client that talks over the already dialed conn.
+func clientOver(t *testing.T, conn net.Conn) schedulepb.SchedulerClient {
+	cc, err := grpc.NewClient("passthrough:///socket",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return conn, nil }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return schedulepb.NewSchedulerClient(cc)
+}
+
+func TestUnixSocket_AllowedOtherUser(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("needs root to connect as another user")
+	}
+	const allowed, stranger = 65534, 65533
+
+	// An owner-only socket cannot even be opened by another user.
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	_, private := startSocketServer(t, s, []int{0})
+	if conn, err := dialAs(t, allowed, private); err == nil {
+		conn.Close()
+		t.Error("other user opened an owner-only socket")
+	}
+
+	// A shared one can, and the peer credentials decide.
+	_, path := startSocketServer(t, s, []int{0, allowed})
+	if fi, _ := os.Stat(filepath.Dir(path)); fi.Mode().Perm() != 0o711 {
+		t.Errorf("shared socket directory mode = %o, want 711", fi.Mode().Perm())
+	}
+	conn, err := dialAs(t, allowed, path)
+	if err != nil {
+		t.Fatalf("allowed user could not connect: %v", err)
+	}
+	if _, err := clientOver(t, conn).ListSchedules(context.Background(), &schedulepb.ListSchedulesRequest{}); err != nil {
+		t.Errorf("allowed user: %v", err)
+	}
+
+	conn, err = dialAs(t, stranger, path)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	_, err = clientOver(t, conn).ListSchedules(context.Background(), &schedulepb.ListSchedulesRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("user not on the list: got %v, want PermissionDenied", err)
+	}
+}
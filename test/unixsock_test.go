@@ -0,0 +1,132 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/unixsock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// startSocketServer serves s on a Unix socket, admitting uids, and returns
+// a client connected to it. More than one uid makes the socket shared.
+func startSocketServer(t *testing.T, srv schedulepb.SchedulerServer, uids []int) (schedulepb.SchedulerClient, string) {
+	// Socket paths are limited to about 100 bytes, so avoid t.TempDir.
+	dir, err := os.MkdirTemp("", "rm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	os.Chmod(dir, 0o711)
+	path := filepath.Join(dir, "run", "remindme.sock")
+
+	lis, err := unixsock.Listen(path, len(uids) > 1)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	unary, stream := unixsock.Authorize(uids)
+	g := grpc.NewServer(grpc.Creds(unixsock.Credentials()),
+		grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	schedulepb.RegisterSchedulerServer(g, srv)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient(unixsock.Scheme+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return schedulepb.NewSchedulerClient(conn), path
+}
+
+func TestUnixSocket_OwnerAllowed(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	client, path := startSocketServer(t, s, []int{os.Getuid()})
+
+	if _, err := client.ListSchedules(context.Background(), &schedulepb.ListSchedulesRequest{}); err != nil {
+		t.Fatalf("ListSchedules: %v", err)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %o, want 600", perm)
+	}
+	di, _ := os.Stat(filepath.Dir(path))
+	if perm := di.Mode().Perm(); perm != 0o700 {
+		t.Errorf("socket directory mode = %o, want 700", perm)
+	}
+}
+
+func TestUnixSocket_OtherUserDenied(t *testing.T) {
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	client, _ := startSocketServer(t, s, []int{os.Getuid() + 1})
+
+	_, err := client.ListSchedules(context.Background(), &schedulepb.ListSchedulesRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("ListSchedules: got %v, want PermissionDenied", err)
+	}
+
+	stream, err := client.WatchEvents(context.Background(), &schedulepb.WatchRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("WatchEvents: got %v, want PermissionDenied", err)
+	}
+}
+
+func TestUnixSocket_Listen(t *testing.T) {
+	dir, err := os.MkdirTemp("", "rm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "remindme.sock")
+
+	lis, err := unixsock.Listen(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unixsock.Listen(path, false); err == nil {
+		t.Error("second Listen on a live socket: want error")
+	}
+	lis.Close()
+
+	// A stale file left by a crashed server is replaced.
+	os.WriteFile(path, nil, 0o600)
+	lis, err = unixsock.Listen(path, false)
+	if err != nil {
+		t.Fatalf("Listen over a stale file: %v", err)
+	}
+	lis.Close()
+
+	// A shared socket can be reached by others, but not under a directory
+	// only its owner may enter.
+	if _, err := unixsock.Listen(filepath.Join(dir, "sub", "remindme.sock"), true); err == nil {
+		t.Error("shared socket under a 0700 directory: want error")
+	}
+	os.Chmod(dir, 0o711)
+	lis, err = unixsock.Listen(filepath.Join(dir, "sub", "remindme.sock"), true)
+	if err != nil {
+		t.Fatalf("shared Listen: %v", err)
+	}
+	defer lis.Close()
+	for path, want := range map[string]os.FileMode{filepath.Join(dir, "sub"): 0o711, filepath.Join(dir, "sub", "remindme.sock"): 0o666} {
+		if fi, err := os.Stat(path); err != nil {
+			t.Error(err)
+		} else if fi.Mode().Perm() != want {
+			t.Errorf("%s: mode %o, want %o", path, fi.Mode().Perm(), want)
+		}
+	}
+}
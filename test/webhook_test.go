@@ -0,0 +1,170 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/notify"
+)
+
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookEndpoint answers with the given statuses in turn, then 200, and
+// passes on every request it receives.
+func webhookEndpoint(t *testing.T, statuses ...int) (*httptest.Server, chan delivery) {
+	got := make(chan delivery, 10)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- delivery{r.Header, body}
+		if n := int(calls.Add(1)); n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func waitDelivery(t *testing.T, got chan delivery) delivery {
+	t.Helper()
+	select {
+	case d := <-got:
+		return d
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a webhook delivery")
+		return delivery{}
+	}
+}
+
+func TestWebhook_SignedPayload(t *testing.T) {
+	srv, got := webhookEndpoint(t)
+	n, err := notify.New("webhook", notify.Options{"webhook_urls": srv.URL, "webhook_secret": "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fired := time.Date(2025, 7, 22, 18, 0, 0, 0, time.UTC)
+	if err := n.Notify(notify.Notification{ID: "id-1", Title: "Standup", Memo: "room 3", URL: "https://example.com", Time: fired}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := waitDelivery(t, got)
+	var p notify.WebhookPayload
+	if err := json.Unmarshal(d.body, &p); err != nil {
+		t.Fatal(err)
+	}
+	want := notify.WebhookPayload{ID: "id-1", Title: "Standup", Memo: "room 3", URL: "https://example.com", FireTime: "2025-07-22T18:00:00Z"}
+	if p != want {
+		t.Errorf("payload = %+v, want %+v", p, want)
+	}
+	sig := notify.WebhookSignature([]byte("s3cret"), d.header.Get(notify.WebhookTimestampHeader), d.body)
+	if d.header.Get(notify.WebhookSignatureHeader) != "sha256="+sig {
+		t.Errorf("signature %q does not match the body", d.header.Get(notify.WebhookSignatureHeader))
+	}
+	if d.header.Get(notify.WebhookDeliveryHeader) == "" {
+		t.Error("missing delivery id")
+	}
+}
+
+func TestWebhook_RetriesThenDeadLetter(t *testing.T) {
+	// Two failures and a success: delivered on the third attempt with the
+	// same delivery id.
+	srv, got := webhookEndpoint(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	dead := filepath.Join(t.TempDir(), "dead.jsonl")
+	opts := notify.Options{"webhook_urls": srv.URL, "webhook_backoff": "5ms", "webhook_retries": "2", "webhook_dead_letter": dead}
+	n, err := notify.New("webhook", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Notify(notify.Notification{ID: "id-1", Title: "Standup"})
+	first := waitDelivery(t, got)
+	for i := 0; i < 2; i++ {
+		if d := waitDelivery(t, got); d.header.Get(notify.WebhookDeliveryHeader) != first.header.Get(notify.WebhookDeliveryHeader) {
+			t.Error("retry has a new delivery id")
+		}
+	}
+
+	// An endpoint that keeps failing ends up in the dead-letter file.
+	failing, got := webhookEndpoint(t, 500, 500, 500)
+	opts["webhook_urls"] = failing.URL
+	n, _ = notify.New("webhook", opts)
+	n.Notify(notify.Notification{ID: "id-2", Title: "Retro"})
+	for i := 0; i < 3; i++ {
+		waitDelivery(t, got)
+	}
+	var data []byte
+	for i := 0; i < 100 && len(data) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		data, _ = os.ReadFile(dead)
+	}
+	var rec notify.DeadLetter
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatalf("dead letter %q: %v", data, err)
+	}
+	if rec.Endpoint != failing.URL || rec.Attempts != 3 || !strings.Contains(rec.Error, "500") || !strings.Contains(string(rec.Payload), `"id-2"`) {
+		t.Errorf("dead letter = %+v", rec)
+	}
+
+	// A client error is not retried.
+	rejecting, got := webhookEndpoint(t, http.StatusBadRequest)
+	opts["webhook_urls"] = rejecting.URL
+	n, _ = notify.New("webhook", opts)
+	n.Notify(notify.Notification{ID: "id-3", Title: "Lunch"})
+	waitDelivery(t, got)
+	select {
+	case <-got:
+		t.Error("400 response was retried")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWebhook_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	dead := filepath.Join(t.TempDir(), "dead.jsonl")
+	n, err := notify.New("webhook", notify.Options{"webhook_urls": slow.URL, "webhook_timeout": "20ms", "webhook_retries": "0", "webhook_dead_letter": dead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Notify(notify.Notification{ID: "id-1", Title: "Standup"})
+	for i := 0; i < 100; i++ {
+		if data, _ := os.ReadFile(dead); strings.Contains(string(data), "deadline exceeded") {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("timed-out delivery was not dead-lettered")
+}
+
+func TestWebhook_Options(t *testing.T) {
+	for _, opts := range []notify.Options{
+		{},
+		{"webhook_urls": "ftp://example.com"},
+		{"webhook_urls": "https://example.com", "webhook_timeout": "soon"},
+		{"webhook_urls": "https://example.com", "webhook_retries": "-1"},
+	} {
+		if _, err := notify.New("webhook", opts); err == nil {
+			t.Errorf("New(webhook, %v): expected error", opts)
+		}
+	}
+
+	// Backends combine, each reading its own options.
+	if _, err := notify.New("freedesktop,webhook", notify.Options{"webhook_urls": "https://example.com"}); err != nil {
+		t.Errorf("combined backends: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/internal/when"
+	"github.com/je0ng3/remindme-cli/internal/zone"
+)
+
+func TestWhen_Parse(t *testing.T) {
+	seoul := time.FixedZone("KST", 9*60*60)
+	// Wednesday afternoon.
+	now := time.Date(2025, 7, 16, 13, 20, 0, 0, seoul)
+
+	cases := []struct {
+		in, want string
+	}{
+		{"2025-07-22 18:00", "2025-07-22 18:00"},
+		{"2025-07-22T18:00+09:00", "2025-07-22 18:00"},
+		{"2025-07-22T09:00Z", "2025-07-22 18:00"},
+		{"in 90m", "2025-07-16 14:50"},
+		{"in 1h30m", "2025-07-16 14:50"},
+		{"in 2 days", "2025-07-18 13:20"},
+		{"30분 후", "2025-07-16 13:50"},
+		{"2시간 뒤", "2025-07-16 15:20"},
+		{"tomorrow 9am", "2025-07-17 09:00"},
+		{"tomorrow", "2025-07-17 09:00"},
+		{"tonight", "2025-07-16 20:00"},
+		{"9am", "2025-07-17 09:00"},
+		{"3:30pm", "2025-07-16 15:30"},
+		{"noon", "2025-07-17 12:00"},
+		{"fri", "2025-07-18 09:00"},
+		{"wed 10:00", "2025-07-23 10:00"},
+		{"wed 15:00", "2025-07-16 15:00"},
+		{"next fri 14:00", "2025-07-25 14:00"},
+		{"next mon at 8pm", "2025-07-21 20:00"},
+		{"7/1 10:00", "2026-07-01 10:00"},
+		{"2025-08-01", "2025-08-01 09:00"},
+		{"내일 오후 3시", "2025-07-17 15:00"},
+		{"오늘 저녁 7시 반", "2025-07-16 19:30"},
+		{"모레 오전 10시 15분", "2025-07-18 10:15"},
+		{"다음주 월요일 10시", "2025-07-21 10:00"},
+		{"이번주 금요일 오후 2시에", "2025-07-18 14:00"},
+		{"금요일", "2025-07-18 09:00"},
+		{"8월 15일 정오", "2025-08-15 12:00"},
+		{"2026년 1월 2일 오전 8시", "2026-01-02 08:00"},
+	}
+	for _, c := range cases {
+		got, err := when.Parse(c.in, now)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", c.in, err)
+			continue
+		}
+		if got.Format("2006-01-02 15:04") != c.want {
+			t.Errorf("Parse(%q) = %s, want %s", c.in, got.Format("2006-01-02 15:04"), c.want)
+		}
+	}
+
+	for _, in := range []string{"", "someday", "next", "다음주", "오후", "2/30", "25:00"} {
+		if _, err := when.Parse(in, now); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", in)
+		}
+	}
+}
+
+func TestWhen_DSTTransitions(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, ny)
+
+	// 02:30 is skipped on the spring-forward day.
+	for _, in := range []string{"2025-03-09 02:30", "2025-03-09T02:30", "3/9 2:30am"} {
+		_, err := when.Parse(in, now)
+		var gap *zone.NonexistentError
+		if !errors.As(err, &gap) {
+			t.Errorf("Parse(%q) = %v, want a NonexistentError", in, err)
+		}
+	}
+
+	// 01:30 happens twice on the fall-back day; the earlier one is used.
+	got, err := when.Parse("2025-11-02 01:30", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2025-11-02T01:30:00-04:00"; got.Format(time.RFC3339) != want {
+		t.Errorf("Parse = %s, want %s", got.Format(time.RFC3339), want)
+	}
+
+	// The day before the gap, "tomorrow 9am" keeps its wall clock.
+	got, _ = when.Parse("tomorrow 9am", time.Date(2025, 3, 8, 12, 0, 0, 0, ny))
+	if want := "2025-03-09T09:00:00-04:00"; got.Format(time.RFC3339) != want {
+		t.Errorf("tomorrow 9am = %s, want %s", got.Format(time.RFC3339), want)
+	}
+}
+
+func TestWhen_EndOfDay(t *testing.T) {
+	now := time.Date(2025, 7, 16, 13, 20, 0, 0, time.UTC)
+	for in, want := range map[string]string{
+		"tomorrow 24:00": "2025-07-18 00:00",
+		"tomorrow 00:00": "2025-07-17 00:00",
+		"24:00":          "2025-07-17 00:00",
+		"7/31 24:00":     "2025-08-01 00:00",
+	} {
+		got, err := when.Parse(in, now)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", in, err)
+			continue
+		}
+		if got.Format("2006-01-02 15:04") != want {
+			t.Errorf("Parse(%q) = %s, want %s", in, got.Format("2006-01-02 15:04"), want)
+		}
+	}
+	if _, err := when.Parse("24:30", now); err == nil {
+		t.Error("Expected Parse(\"24:30\") to fail")
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/je0ng3/remindme-cli/api/proto/schedulepb"
+	"github.com/je0ng3/remindme-cli/internal/recur"
+	"github.com/je0ng3/remindme-cli/internal/watcher"
+	"github.com/je0ng3/remindme-cli/internal/zone"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	loc, err := zone.Load(name)
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	return loc
+}
+
+func TestZone_ResolveGapAndOverlap(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+
+	_, _, err := zone.Resolve(watcher.Layout, "2025-03-09 02:30", ny)
+	var gap *zone.NonexistentError
+	if !errors.As(err, &gap) {
+		t.Fatalf("Expected NonexistentError for a skipped wall time, got %v", err)
+	}
+
+	got, ambiguous, err := zone.Resolve(watcher.Layout, "2025-11-02 01:30", ny)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if !ambiguous {
+		t.Error("Expected 01:30 on the fall-back day to be ambiguous")
+	}
+	if want := "2025-11-02T01:30:00-04:00"; got.Format(time.RFC3339) != want {
+		t.Errorf("Expected the earlier instant %s, got %s", want, got.Format(time.RFC3339))
+	}
+}
+
+func TestRecur_KeepsWallClockAcrossDST(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	rule, err := recur.Parse("daily")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	start := time.Date(2025, 3, 8, 9, 0, 0, 0, ny)
+	next, _, ok := rule.Advance(start, start)
+	if !ok {
+		t.Fatal("Expected a next occurrence")
+	}
+	if want := "2025-03-09T09:00:00-04:00"; next.Format(time.RFC3339) != want {
+		t.Errorf("Expected %s, got %s", want, next.Format(time.RFC3339))
+	}
+
+	// A skipped wall time moves forward by the length of the gap.
+	start = time.Date(2025, 3, 8, 2, 30, 0, 0, ny)
+	next, _, _ = rule.Advance(start, start)
+	if want := "2025-03-09T03:30:00-04:00"; next.Format(time.RFC3339) != want {
+		t.Errorf("Expected %s, got %s", want, next.Format(time.RFC3339))
+	}
+}
+
+func TestAddSchedule_NormalizesToZone(t *testing.T) {
+	mustLoad(t, "America/New_York")
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	ctx := context.TODO()
+
+	res, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
+		Title:    "Fall back",
+		Datetime: "2030-11-03 01:30",
+		Tz:       "America/New_York",
+	})
+	if err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	if !strings.Contains(res.Message, "earlier") {
+		t.Errorf("Expected the ambiguity to be reported, got %q", res.Message)
+	}
+
+	list, _ := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+	sch := list.Schedules[0]
+	if sch.Datetime != "2030-11-03T01:30:00-04:00" || sch.Tz != "America/New_York" {
+		t.Errorf("Unexpected stored time %s in %q", sch.Datetime, sch.Tz)
+	}
+
+	_, err = s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
+		Title:    "Spring forward",
+		Datetime: "2030-03-10 02:30",
+		Tz:       "America/New_York",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a skipped wall time, got %v", err)
+	}
+
+	_, err = s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
+		Title:    "Nowhere",
+		Datetime: "2030-01-01 09:00",
+		Tz:       "Mars/Olympus_Mons",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown zone, got %v", err)
+	}
+}
+
+func TestUpdateSchedule_ZoneKeepsWallClock(t *testing.T) {
+	mustLoad(t, "Asia/Seoul")
+	s, _, cleanup := createTempServer(t)
+	defer cleanup()
+	ctx := context.TODO()
+
+	if _, err := s.AddSchedule(ctx, &schedulepb.ScheduleRequest{
+		Title:    "Call",
+		Datetime: "2030-06-01 09:00",
+		Tz:       "UTC",
+	}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	list, _ := s.ListSchedules(ctx, &schedulepb.ListSchedulesRequest{})
+	id := list.Schedules[0].Id
+
+	if _, err := s.UpdateSchedule(ctx, &schedulepb.UpdateScheduleRequest{
+		Id:         id,
+		Schedule:   &schedulepb.ScheduleRequest{Tz: "Asia/Seoul"},
+		UpdateMask: []string{"tz"},
+	}); err != nil {
+		t.Fatalf("UpdateSchedule failed: %v", err)
+	}
+	got, _ := s.GetSchedule(ctx, &schedulepb.ScheduleId{Id: id})
+	if got.Datetime != "2030-06-01T09:00:00+09:00" {
+		t.Errorf("Expected 09:00 Seoul time, got %s", got.Datetime)
+	}
+}